@@ -0,0 +1,41 @@
+# Typed clientset, listers and informers for the DrainerConfig API. Only
+# v1alpha2 is generated, since client-gen expects every API version to live in
+# a package named after the version, which api (v1alpha1) does not.
+
+CLIENT_GEN := $(abspath hack/bin/client-gen)
+LISTER_GEN := $(abspath hack/bin/lister-gen)
+INFORMER_GEN := $(abspath hack/bin/informer-gen)
+CODE_GENERATOR_VERSION := v0.34.1
+
+MODULE := github.com/giantswarm/node-operator
+CLIENT_DIR := pkg/client
+CLIENT_PKG := $(MODULE)/$(CLIENT_DIR)
+CLIENT_INPUT := api/v1alpha2
+
+$(CLIENT_GEN) $(LISTER_GEN) $(INFORMER_GEN):
+	@echo "$(BUILD_COLOR)Building $(notdir $@)$(NO_COLOR)"
+	GOBIN=$(dir $@) go install k8s.io/code-generator/cmd/$(notdir $@)@$(CODE_GENERATOR_VERSION)
+
+.PHONY: generate-client
+generate-client: $(CLIENT_GEN) $(LISTER_GEN) $(INFORMER_GEN)
+	@echo "$(GEN_COLOR)Generating clientset, listers and informers$(NO_COLOR)"
+	rm -rf $(CLIENT_DIR)
+	$(CLIENT_GEN) \
+	--go-header-file hack/boilerplate.go.txt \
+	--clientset-name versioned \
+	--input-base $(MODULE) \
+	--input $(CLIENT_INPUT) \
+	--output-dir $(CLIENT_DIR)/clientset \
+	--output-pkg $(CLIENT_PKG)/clientset
+	$(LISTER_GEN) \
+	--go-header-file hack/boilerplate.go.txt \
+	--output-dir $(CLIENT_DIR)/listers \
+	--output-pkg $(CLIENT_PKG)/listers \
+	./$(CLIENT_INPUT)
+	$(INFORMER_GEN) \
+	--go-header-file hack/boilerplate.go.txt \
+	--versioned-clientset-package $(CLIENT_PKG)/clientset/versioned \
+	--listers-package $(CLIENT_PKG)/listers \
+	--output-dir $(CLIENT_DIR)/informers \
+	--output-pkg $(CLIENT_PKG)/informers \
+	./$(CLIENT_INPUT)
@@ -0,0 +1,257 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+)
+
+const (
+	// ConversionDataAnnotation holds the fields of a DrainerConfig which
+	// cannot be represented in the version it got converted to, so that
+	// converting it back does not lose any information.
+	ConversionDataAnnotation = "core.giantswarm.io/conversion-data"
+)
+
+const (
+	// convertedConditionReason is the reason of conditions converted from
+	// v1alpha1, which does not know about reasons.
+	convertedConditionReason = "Converted"
+)
+
+// conversionData is stored in the ConversionDataAnnotation of v1alpha2
+// DrainerConfigs converted from v1alpha1.
+type conversionData struct {
+	Spec   DrainerConfigSpec   `json:"spec"`
+	Status DrainerConfigStatus `json:"status"`
+}
+
+// hubConversionData is stored in the ConversionDataAnnotation of v1alpha1
+// DrainerConfigs converted from v1alpha2.
+type hubConversionData struct {
+	Spec   v1alpha2.DrainerConfigSpec   `json:"spec"`
+	Status v1alpha2.DrainerConfigStatus `json:"status"`
+}
+
+// ConvertTo converts the DrainerConfig to the v1alpha2 hub version.
+func (src *DrainerConfig) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1alpha2.DrainerConfig)
+	if !ok {
+		return fmt.Errorf("expected *v1alpha2.DrainerConfig, got %T", dstRaw)
+	}
+
+	var restored hubConversionData
+	hasRestored, err := unmarshalConversionData(src.ObjectMeta, &restored)
+	if err != nil {
+		return err
+	}
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = v1alpha2.DrainerConfigSpec{
+		Node: v1alpha2.DrainerConfigSpecNode{
+			Name: src.Spec.Guest.Node.Name,
+		},
+		Policy: convertDrainPolicyTo(src.Spec.DrainPolicy),
+		WorkloadCluster: v1alpha2.DrainerConfigSpecWorkloadCluster{
+			API: v1alpha2.DrainerConfigSpecWorkloadClusterAPI{
+				Endpoint: src.Spec.Guest.Cluster.API.Endpoint,
+			},
+			ID: src.Spec.Guest.Cluster.ID,
+		},
+	}
+
+	if hasRestored {
+		dst.Spec.Action = restored.Spec.Action
+		dst.Spec.Cancel = restored.Spec.Cancel
+		dst.Spec.ClusterAPI = restored.Spec.ClusterAPI
+		dst.Spec.Escalation = restored.Spec.Escalation
+		dst.Spec.OnDelete = restored.Spec.OnDelete
+		dst.Spec.Preflight = restored.Spec.Preflight
+		dst.Spec.Retry = restored.Spec.Retry
+		dst.Spec.Taints = restored.Spec.Taints
+		dst.Spec.UncordonOnCancel = restored.Spec.UncordonOnCancel
+		dst.Spec.VolumeDetach = restored.Spec.VolumeDetach
+		dst.Spec.WorkloadCluster.Credentials = restored.Spec.WorkloadCluster.Credentials
+	}
+
+	dst.Status = v1alpha2.DrainerConfigStatus{}
+	for _, c := range src.Status.Conditions {
+		converted := metav1.Condition{
+			LastTransitionTime: c.LastTransitionTime,
+			Reason:             convertedConditionReason,
+			Status:             metav1.ConditionStatus(c.Status),
+			Type:               c.Type,
+		}
+
+		// Reasons and messages only survive as long as the status of the
+		// condition did not change in v1alpha1.
+		if hasRestored {
+			r := findHubCondition(restored.Status.Conditions, c.Type)
+			if r != nil && r.Status == converted.Status && r.LastTransitionTime.Equal(&converted.LastTransitionTime) {
+				converted.Message = r.Message
+				converted.ObservedGeneration = r.ObservedGeneration
+				converted.Reason = r.Reason
+			}
+		}
+
+		dst.Status.Conditions = append(dst.Status.Conditions, converted)
+
+		if c.Type == DrainerConfigStatusTypeDraining && !c.LastHeartbeatTime.IsZero() {
+			heartbeat := c.LastHeartbeatTime
+			dst.Status.LastHeartbeatTime = &heartbeat
+		}
+	}
+	if hasRestored {
+		dst.Status.Drain = restored.Status.Drain
+		dst.Status.ObservedGeneration = restored.Status.ObservedGeneration
+		dst.Status.Preflight = restored.Status.Preflight
+		dst.Status.UnevictedPods = restored.Status.UnevictedPods
+		if dst.Status.LastHeartbeatTime == nil {
+			dst.Status.LastHeartbeatTime = restored.Status.LastHeartbeatTime
+		}
+	}
+
+	return marshalConversionData(&dst.ObjectMeta, conversionData{Spec: src.Spec, Status: src.Status})
+}
+
+// ConvertFrom converts the given v1alpha2 hub version to this DrainerConfig.
+func (dst *DrainerConfig) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1alpha2.DrainerConfig)
+	if !ok {
+		return fmt.Errorf("expected *v1alpha2.DrainerConfig, got %T", srcRaw)
+	}
+
+	var restored conversionData
+	hasRestored, err := unmarshalConversionData(src.ObjectMeta, &restored)
+	if err != nil {
+		return err
+	}
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = DrainerConfigSpec{
+		DrainPolicy: convertDrainPolicyFrom(src.Spec.Policy),
+		Guest: DrainerConfigSpecGuest{
+			Cluster: DrainerConfigSpecGuestCluster{
+				API: DrainerConfigSpecGuestClusterAPI{
+					Endpoint: src.Spec.WorkloadCluster.API.Endpoint,
+				},
+				ID: src.Spec.WorkloadCluster.ID,
+			},
+			Node: DrainerConfigSpecGuestNode{
+				Name: src.Spec.Node.Name,
+			},
+		},
+	}
+	if hasRestored {
+		dst.Spec.VersionBundle = restored.Spec.VersionBundle
+	}
+
+	dst.Status = DrainerConfigStatus{}
+	for _, c := range src.Status.Conditions {
+		converted := DrainerConfigStatusCondition{
+			LastHeartbeatTime:  c.LastTransitionTime,
+			LastTransitionTime: c.LastTransitionTime,
+			Status:             string(c.Status),
+			Type:               c.Type,
+		}
+
+		if c.Type == v1alpha2.ConditionTypeDraining && src.Status.LastHeartbeatTime != nil {
+			converted.LastHeartbeatTime = *src.Status.LastHeartbeatTime
+		} else if hasRestored {
+			r := findCondition(restored.Status.Conditions, c.Type)
+			if r != nil && r.Status == converted.Status && r.LastTransitionTime.Equal(&converted.LastTransitionTime) {
+				converted.LastHeartbeatTime = r.LastHeartbeatTime
+			}
+		}
+
+		dst.Status.Conditions = append(dst.Status.Conditions, converted)
+	}
+
+	return marshalConversionData(&dst.ObjectMeta, hubConversionData{Spec: src.Spec, Status: src.Status})
+}
+
+func convertDrainPolicyTo(p *DrainerConfigSpecDrainPolicy) *v1alpha2.DrainerConfigSpecPolicy {
+	if p == nil {
+		return nil
+	}
+
+	return &v1alpha2.DrainerConfigSpecPolicy{
+		DeleteEmptyDirData:              p.DeleteEmptyDirData,
+		DisableEviction:                 p.DisableEviction,
+		Force:                           p.Force,
+		GracePeriodSeconds:              p.GracePeriodSeconds,
+		SkipWaitForDeleteTimeoutSeconds: p.SkipWaitForDeleteTimeoutSeconds,
+		Timeout:                         p.Timeout,
+	}
+}
+
+func convertDrainPolicyFrom(p *v1alpha2.DrainerConfigSpecPolicy) *DrainerConfigSpecDrainPolicy {
+	if p == nil {
+		return nil
+	}
+
+	return &DrainerConfigSpecDrainPolicy{
+		DeleteEmptyDirData:              p.DeleteEmptyDirData,
+		DisableEviction:                 p.DisableEviction,
+		Force:                           p.Force,
+		GracePeriodSeconds:              p.GracePeriodSeconds,
+		SkipWaitForDeleteTimeoutSeconds: p.SkipWaitForDeleteTimeoutSeconds,
+		Timeout:                         p.Timeout,
+	}
+}
+
+func findCondition(conditions []DrainerConfigStatusCondition, t string) *DrainerConfigStatusCondition {
+	for i := range conditions {
+		if conditions[i].Type == t {
+			return &conditions[i]
+		}
+	}
+
+	return nil
+}
+
+func findHubCondition(conditions []metav1.Condition, t string) *metav1.Condition {
+	for i := range conditions {
+		if conditions[i].Type == t {
+			return &conditions[i]
+		}
+	}
+
+	return nil
+}
+
+// marshalConversionData stores the given data in the ConversionDataAnnotation
+// of the given object.
+func marshalConversionData(m *metav1.ObjectMeta, data interface{}) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	if m.Annotations == nil {
+		m.Annotations = map[string]string{}
+	}
+	m.Annotations[ConversionDataAnnotation] = string(b)
+
+	return nil
+}
+
+// unmarshalConversionData reads the ConversionDataAnnotation of the given
+// object into data. It returns false if there is no such annotation.
+func unmarshalConversionData(m metav1.ObjectMeta, data interface{}) (bool, error) {
+	s, ok := m.Annotations[ConversionDataAnnotation]
+	if !ok {
+		return false, nil
+	}
+
+	err := json.Unmarshal([]byte(s), data)
+	if err != nil {
+		return false, fmt.Errorf("failed to unmarshal %s annotation: %w", ConversionDataAnnotation, err)
+	}
+
+	return true, nil
+}
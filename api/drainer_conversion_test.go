@@ -0,0 +1,298 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+)
+
+func Test_ConvertTo_RoundTrip(t *testing.T) {
+	transition := metav1.NewTime(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	heartbeat := metav1.NewTime(transition.Add(time.Minute))
+
+	testCases := []struct {
+		name          string
+		drainerConfig DrainerConfig
+	}{
+		{
+			name: "case 0: empty DrainerConfig",
+		},
+		{
+			name: "case 1: DrainerConfig with drain policy, version bundle and conditions",
+			drainerConfig: DrainerConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{"foo": "bar"},
+					Name:        "node-1",
+					Namespace:   "abc12",
+				},
+				Spec: DrainerConfigSpec{
+					DrainPolicy: &DrainerConfigSpecDrainPolicy{
+						Force:              boolPtr(false),
+						GracePeriodSeconds: intPtr(30),
+						Timeout:            &metav1.Duration{Duration: 10 * time.Minute},
+					},
+					Guest: DrainerConfigSpecGuest{
+						Cluster: DrainerConfigSpecGuestCluster{
+							API: DrainerConfigSpecGuestClusterAPI{
+								Endpoint: "api.abc12.example.com",
+							},
+							ID: "abc12",
+						},
+						Node: DrainerConfigSpecGuestNode{
+							Name: "ip-10-1-2-3.eu-central-1.compute.internal",
+						},
+					},
+					VersionBundle: DrainerConfigSpecVersionBundle{
+						Version: "0.2.0",
+					},
+				},
+				Status: DrainerConfigStatus{
+					Conditions: []DrainerConfigStatusCondition{
+						{
+							LastHeartbeatTime:  heartbeat,
+							LastTransitionTime: transition,
+							Status:             DrainerConfigStatusStatusTrue,
+							Type:               DrainerConfigStatusTypeCordoned,
+						},
+						{
+							LastHeartbeatTime:  heartbeat,
+							LastTransitionTime: transition,
+							Status:             DrainerConfigStatusStatusTrue,
+							Type:               DrainerConfigStatusTypeDraining,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			src := tc.drainerConfig.DeepCopy()
+
+			hub := &v1alpha2.DrainerConfig{}
+			err := src.ConvertTo(hub)
+			if err != nil {
+				t.Fatalf("ConvertTo() returned error %#v", err)
+			}
+
+			dst := &DrainerConfig{}
+			err = dst.ConvertFrom(hub)
+			if err != nil {
+				t.Fatalf("ConvertFrom() returned error %#v", err)
+			}
+
+			delete(dst.Annotations, ConversionDataAnnotation)
+			if len(dst.Annotations) == 0 {
+				dst.Annotations = nil
+			}
+
+			if !cmp.Equal(&tc.drainerConfig, dst) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(&tc.drainerConfig, dst))
+			}
+		})
+	}
+}
+
+func Test_ConvertFrom_RoundTrip(t *testing.T) {
+	transition := metav1.NewTime(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	heartbeat := metav1.NewTime(transition.Add(time.Minute))
+
+	testCases := []struct {
+		name          string
+		drainerConfig v1alpha2.DrainerConfig
+	}{
+		{
+			name: "case 0: empty DrainerConfig",
+		},
+		{
+			name: "case 1: DrainerConfig with policy and conditions",
+			drainerConfig: v1alpha2.DrainerConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Generation: 2,
+					Name:       "node-1",
+					Namespace:  "abc12",
+				},
+				Spec: v1alpha2.DrainerConfigSpec{
+					Action: v1alpha2.ActionTaint,
+					Cancel: true,
+					ClusterAPI: &v1alpha2.DrainerConfigSpecClusterAPI{
+						Machine: "abc12-md-0-x7k2p",
+					},
+					Escalation: &v1alpha2.DrainerConfigSpecEscalation{
+						DeleteAfter:      &metav1.Duration{Duration: 10 * time.Minute},
+						ForceDeleteAfter: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+					Node: v1alpha2.DrainerConfigSpecNode{
+						Name: "ip-10-1-2-3.eu-central-1.compute.internal",
+					},
+					OnDelete: v1alpha2.OnDeleteUncordonNode,
+					Policy: &v1alpha2.DrainerConfigSpecPolicy{
+						DisableEviction: boolPtr(true),
+					},
+					Preflight: &v1alpha2.DrainerConfigSpecPreflight{
+						WaitForBlockers: true,
+					},
+					Retry: &v1alpha2.DrainerConfigSpecRetry{
+						Backoff:               &metav1.Duration{Duration: 2 * time.Minute},
+						EscalateToDeleteAfter: 2,
+						MaxAttempts:           3,
+					},
+					Taints: []corev1.Taint{
+						{
+							Effect: corev1.TaintEffectNoSchedule,
+							Key:    "example.com/hardware-issue",
+						},
+					},
+					UncordonOnCancel: true,
+					VolumeDetach: &v1alpha2.DrainerConfigSpecVolumeDetach{
+						Timeout: &metav1.Duration{Duration: 10 * time.Minute},
+					},
+					WorkloadCluster: v1alpha2.DrainerConfigSpecWorkloadCluster{
+						API: v1alpha2.DrainerConfigSpecWorkloadClusterAPI{
+							Endpoint: "api.abc12.example.com",
+						},
+						Credentials: &v1alpha2.DrainerConfigSpecWorkloadClusterCredentials{
+							Type: v1alpha2.CredentialsTypeServiceAccountToken,
+							Secret: &v1alpha2.DrainerConfigSpecWorkloadClusterCredentialsSecret{
+								Name: "abc12-node-operator-token",
+							},
+						},
+						ID: "abc12",
+					},
+				},
+				Status: v1alpha2.DrainerConfigStatus{
+					Conditions: []metav1.Condition{
+						{
+							LastTransitionTime: transition,
+							Message:            "Evicting pods from node node-1",
+							ObservedGeneration: 2,
+							Reason:             v1alpha2.ReasonDrainStarted,
+							Status:             metav1.ConditionTrue,
+							Type:               v1alpha2.ConditionTypeDraining,
+						},
+						{
+							LastTransitionTime: transition,
+							Message:            "Failed to drain node node-1",
+							ObservedGeneration: 2,
+							Reason:             v1alpha2.ReasonPodDisruptionBudgetBlocked,
+							Status:             metav1.ConditionFalse,
+							Type:               v1alpha2.ConditionTypeFailed,
+						},
+					},
+					Drain: &v1alpha2.DrainerConfigStatusDrain{
+						Attempt:  2,
+						Failures: 1,
+						Phase:    v1alpha2.DrainPhaseEvicting,
+						Progress: &v1alpha2.DrainerConfigStatusDrainProgress{
+							Evicted:          42,
+							LastProgressTime: heartbeat,
+							Remaining:        15,
+							StartTime:        transition,
+							Total:            57,
+						},
+						StartTime: transition,
+					},
+					LastHeartbeatTime:  &heartbeat,
+					ObservedGeneration: 2,
+					Preflight: &v1alpha2.DrainerConfigStatusPreflight{
+						Blocked:                        true,
+						PodDisruptionBudgetBlockedPods: []string{"default/web-0"},
+						Time:                           transition,
+					},
+					UnevictedPods: []v1alpha2.DrainerConfigStatusPod{
+						{
+							Name:      "web-0",
+							Namespace: "default",
+							OwnerKind: "StatefulSet",
+							Reason:    v1alpha2.UnevictedPodReasonPodDisruptionBudget,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hub := tc.drainerConfig.DeepCopy()
+
+			spoke := &DrainerConfig{}
+			err := spoke.ConvertFrom(hub)
+			if err != nil {
+				t.Fatalf("ConvertFrom() returned error %#v", err)
+			}
+
+			dst := &v1alpha2.DrainerConfig{}
+			err = spoke.ConvertTo(dst)
+			if err != nil {
+				t.Fatalf("ConvertTo() returned error %#v", err)
+			}
+
+			delete(dst.Annotations, ConversionDataAnnotation)
+			if len(dst.Annotations) == 0 {
+				dst.Annotations = nil
+			}
+
+			if !cmp.Equal(&tc.drainerConfig, dst) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(&tc.drainerConfig, dst))
+			}
+		})
+	}
+}
+
+func Test_ConvertTo_ChangedCondition(t *testing.T) {
+	transition := metav1.NewTime(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	hub := &v1alpha2.DrainerConfig{
+		Status: v1alpha2.DrainerConfigStatus{
+			Conditions: []metav1.Condition{
+				{
+					LastTransitionTime: transition,
+					Message:            "Evicting pods from node node-1",
+					Reason:             v1alpha2.ReasonDrainStarted,
+					Status:             metav1.ConditionTrue,
+					Type:               v1alpha2.ConditionTypeDraining,
+				},
+			},
+		},
+	}
+
+	spoke := &DrainerConfig{}
+	err := spoke.ConvertFrom(hub)
+	if err != nil {
+		t.Fatalf("ConvertFrom() returned error %#v", err)
+	}
+
+	// A v1alpha1 client flips the condition, so the reason and message stored
+	// for the old status must not be restored.
+	spoke.Status.Conditions[0].Status = DrainerConfigStatusStatusFalse
+	spoke.Status.Conditions[0].LastTransitionTime = metav1.NewTime(transition.Add(time.Minute))
+
+	dst := &v1alpha2.DrainerConfig{}
+	err = spoke.ConvertTo(dst)
+	if err != nil {
+		t.Fatalf("ConvertTo() returned error %#v", err)
+	}
+
+	c := dst.Status.Conditions[0]
+	if c.Reason != convertedConditionReason {
+		t.Fatalf("Reason == %q, expected %q", c.Reason, convertedConditionReason)
+	}
+	if c.Message != "" {
+		t.Fatalf("Message == %q, expected empty message", c.Message)
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func intPtr(i int) *int {
+	return &i
+}
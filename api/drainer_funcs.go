@@ -4,30 +4,85 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+func (s DrainerConfigStatus) HasCordonedCondition() bool {
+	return hasDrainerConfigCondition(s.Conditions, DrainerConfigStatusStatusTrue, DrainerConfigStatusTypeCordoned)
+}
+
 func (s DrainerConfigStatus) HasDrainedCondition() bool {
 	return hasDrainerConfigCondition(s.Conditions, DrainerConfigStatusStatusTrue, DrainerConfigStatusTypeDrained)
 }
 
+func (s DrainerConfigStatus) HasDrainingCondition() bool {
+	return hasDrainerConfigCondition(s.Conditions, DrainerConfigStatusStatusTrue, DrainerConfigStatusTypeDraining)
+}
+
+func (s DrainerConfigStatus) HasFailedCondition() bool {
+	return hasDrainerConfigCondition(s.Conditions, DrainerConfigStatusStatusTrue, DrainerConfigStatusTypeFailed)
+}
+
+func (s DrainerConfigStatus) HasPendingCondition() bool {
+	return hasDrainerConfigCondition(s.Conditions, DrainerConfigStatusStatusTrue, DrainerConfigStatusTypePending)
+}
+
 func (s DrainerConfigStatus) HasTimeoutCondition() bool {
 	return hasDrainerConfigCondition(s.Conditions, DrainerConfigStatusStatusTrue, DrainerConfigStatusTypeTimeout)
 }
 
+func (s DrainerConfigStatus) NewCordonedCondition() DrainerConfigStatusCondition {
+	return newDrainerConfigCondition(DrainerConfigStatusStatusTrue, DrainerConfigStatusTypeCordoned)
+}
+
 func (s DrainerConfigStatus) NewDrainedCondition() DrainerConfigStatusCondition {
-	return DrainerConfigStatusCondition{
-		LastHeartbeatTime:  metav1.Now(),
-		LastTransitionTime: metav1.Now(),
-		Status:             DrainerConfigStatusStatusTrue,
-		Type:               DrainerConfigStatusTypeDrained,
-	}
+	return newDrainerConfigCondition(DrainerConfigStatusStatusTrue, DrainerConfigStatusTypeDrained)
+}
+
+func (s DrainerConfigStatus) NewDrainingCondition() DrainerConfigStatusCondition {
+	return newDrainerConfigCondition(DrainerConfigStatusStatusTrue, DrainerConfigStatusTypeDraining)
+}
+
+func (s DrainerConfigStatus) NewFailedCondition() DrainerConfigStatusCondition {
+	return newDrainerConfigCondition(DrainerConfigStatusStatusTrue, DrainerConfigStatusTypeFailed)
+}
+
+func (s DrainerConfigStatus) NewPendingCondition() DrainerConfigStatusCondition {
+	return newDrainerConfigCondition(DrainerConfigStatusStatusTrue, DrainerConfigStatusTypePending)
 }
 
 func (s DrainerConfigStatus) NewTimeoutCondition() DrainerConfigStatusCondition {
-	return DrainerConfigStatusCondition{
-		LastHeartbeatTime:  metav1.Now(),
-		LastTransitionTime: metav1.Now(),
-		Status:             DrainerConfigStatusStatusTrue,
-		Type:               DrainerConfigStatusTypeTimeout,
+	return newDrainerConfigCondition(DrainerConfigStatusStatusTrue, DrainerConfigStatusTypeTimeout)
+}
+
+// GetCondition returns the condition of the given type, if any.
+func (s DrainerConfigStatus) GetCondition(t string) (DrainerConfigStatusCondition, bool) {
+	for _, c := range s.Conditions {
+		if c.Type == t {
+			return c, true
+		}
+	}
+
+	return DrainerConfigStatusCondition{}, false
+}
+
+// SetCondition adds the given condition to the status, replacing any
+// condition of the same type. In case the status of the condition did not
+// change only its LastHeartbeatTime is updated, so LastTransitionTime keeps
+// reflecting when the status actually changed.
+func (s *DrainerConfigStatus) SetCondition(c DrainerConfigStatusCondition) {
+	for i, existing := range s.Conditions {
+		if existing.Type != c.Type {
+			continue
+		}
+
+		if existing.Status == c.Status {
+			s.Conditions[i].LastHeartbeatTime = c.LastHeartbeatTime
+		} else {
+			s.Conditions[i] = c
+		}
+
+		return
 	}
+
+	s.Conditions = append(s.Conditions, c)
 }
 
 func hasDrainerConfigCondition(conditions []DrainerConfigStatusCondition, s string, t string) bool {
@@ -39,3 +94,12 @@ func hasDrainerConfigCondition(conditions []DrainerConfigStatusCondition, s stri
 
 	return false
 }
+
+func newDrainerConfigCondition(s string, t string) DrainerConfigStatusCondition {
+	return DrainerConfigStatusCondition{
+		LastHeartbeatTime:  metav1.Now(),
+		LastTransitionTime: metav1.Now(),
+		Status:             s,
+		Type:               t,
+	}
+}
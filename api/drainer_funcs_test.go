@@ -124,3 +124,90 @@ func Test_NewTimeoutCondition(t *testing.T) {
 		t.Fatalf("DrainerConfigStatus doesn't have Timeout condition after NewTimeoutCondition() call")
 	}
 }
+
+func Test_NewLifecycleConditions(t *testing.T) {
+	testCases := []struct {
+		name         string
+		newCondition func(DrainerConfigStatus) DrainerConfigStatusCondition
+		hasCondition func(DrainerConfigStatus) bool
+	}{
+		{
+			name:         "case 0: Pending condition",
+			newCondition: DrainerConfigStatus.NewPendingCondition,
+			hasCondition: DrainerConfigStatus.HasPendingCondition,
+		},
+		{
+			name:         "case 1: Cordoned condition",
+			newCondition: DrainerConfigStatus.NewCordonedCondition,
+			hasCondition: DrainerConfigStatus.HasCordonedCondition,
+		},
+		{
+			name:         "case 2: Draining condition",
+			newCondition: DrainerConfigStatus.NewDrainingCondition,
+			hasCondition: DrainerConfigStatus.HasDrainingCondition,
+		},
+		{
+			name:         "case 3: Failed condition",
+			newCondition: DrainerConfigStatus.NewFailedCondition,
+			hasCondition: DrainerConfigStatus.HasFailedCondition,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			status := DrainerConfigStatus{}
+			if tc.hasCondition(status) {
+				t.Fatalf("empty DrainerConfigStatus has condition")
+			}
+
+			status.SetCondition(tc.newCondition(status))
+			if !tc.hasCondition(status) {
+				t.Fatalf("DrainerConfigStatus doesn't have condition after SetCondition() call")
+			}
+			if status.HasDrainedCondition() {
+				t.Fatalf("DrainerConfigStatus has Drained condition after setting another condition")
+			}
+		})
+	}
+}
+
+func Test_SetCondition(t *testing.T) {
+	transition := metav1.NewTime(time.Now().Add(-time.Hour))
+
+	status := DrainerConfigStatus{
+		Conditions: []DrainerConfigStatusCondition{
+			{
+				LastHeartbeatTime:  transition,
+				LastTransitionTime: transition,
+				Status:             DrainerConfigStatusStatusTrue,
+				Type:               DrainerConfigStatusTypeDraining,
+			},
+		},
+	}
+
+	// Setting a condition with the same status only updates the heartbeat.
+	status.SetCondition(status.NewDrainingCondition())
+	if len(status.Conditions) != 1 {
+		t.Fatalf("expected 1 condition, got %d", len(status.Conditions))
+	}
+	if !status.Conditions[0].LastTransitionTime.Equal(&transition) {
+		t.Fatalf("LastTransitionTime changed although status did not change")
+	}
+	if !status.Conditions[0].LastHeartbeatTime.After(transition.Time) {
+		t.Fatalf("LastHeartbeatTime was not updated")
+	}
+
+	// Setting a condition with another status replaces it.
+	c := status.NewDrainingCondition()
+	c.Status = DrainerConfigStatusStatusFalse
+	status.SetCondition(c)
+	if len(status.Conditions) != 1 {
+		t.Fatalf("expected 1 condition, got %d", len(status.Conditions))
+	}
+	if status.HasDrainingCondition() {
+		t.Fatalf("DrainerConfigStatus has Draining condition after setting it to False")
+	}
+	if status.Conditions[0].LastTransitionTime.Equal(&transition) {
+		t.Fatalf("LastTransitionTime did not change although status changed")
+	}
+}
@@ -5,14 +5,30 @@ import (
 )
 
 const (
-	DrainerConfigStatusStatusTrue = "True"
+	DrainerConfigStatusStatusFalse = "False"
+	DrainerConfigStatusStatusTrue  = "True"
 )
 
 const (
+	// DrainerConfigStatusTypePending is set once the operator picked up the
+	// DrainerConfig and before the node gets cordoned.
+	DrainerConfigStatusTypePending = "Pending"
+	// DrainerConfigStatusTypeCordoned is set once the node got cordoned.
+	DrainerConfigStatusTypeCordoned = "Cordoned"
+	// DrainerConfigStatusTypeDraining is true while pods are being evicted
+	// from the node. Its LastHeartbeatTime is updated while the drain is in
+	// flight.
+	DrainerConfigStatusTypeDraining = "Draining"
+	// DrainerConfigStatusTypeDrained is set once the node got drained.
 	DrainerConfigStatusTypeDrained = "Drained"
+	// DrainerConfigStatusTypeFailed is true when the last attempt to cordon or
+	// drain the node failed.
+	DrainerConfigStatusTypeFailed = "Failed"
 )
 
 const (
+	// DrainerConfigStatusTypeTimeout is set once the operator gave up draining
+	// the node.
 	DrainerConfigStatusTypeTimeout = "Timeout"
 )
 
@@ -29,7 +45,6 @@ func NewDrainerTypeMeta() metav1.TypeMeta {
 
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
-// +kubebuilder:storageversion
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:categories=common;giantswarm
 // +k8s:openapi-gen=true
@@ -44,10 +59,45 @@ type DrainerConfig struct {
 
 // +k8s:openapi-gen=true
 type DrainerConfigSpec struct {
+	// DrainPolicy configures how the node is drained. Unset fields fall back
+	// to the operator defaults for the kind of node being drained.
+	// +kubebuilder:validation:Optional
+	DrainPolicy   *DrainerConfigSpecDrainPolicy  `json:"drainPolicy,omitempty"`
 	Guest         DrainerConfigSpecGuest         `json:"guest"`
 	VersionBundle DrainerConfigSpecVersionBundle `json:"versionBundle"`
 }
 
+// DrainerConfigSpecDrainPolicy mirrors the options of kubectl drain.
+// +k8s:openapi-gen=true
+type DrainerConfigSpecDrainPolicy struct {
+	// DeleteEmptyDirData defines whether pods using emptyDir volumes are
+	// drained anyway, in which case the data in those volumes is lost.
+	// +kubebuilder:validation:Optional
+	DeleteEmptyDirData *bool `json:"deleteEmptyDirData,omitempty"`
+	// DisableEviction defines whether pods are deleted instead of being
+	// evicted. Deleting pods bypasses PodDisruptionBudgets.
+	// +kubebuilder:validation:Optional
+	DisableEviction *bool `json:"disableEviction,omitempty"`
+	// Force defines whether pods not managed by a controller are drained too.
+	// +kubebuilder:validation:Optional
+	Force *bool `json:"force,omitempty"`
+	// GracePeriodSeconds is the period of time given to each pod to terminate
+	// gracefully. -1 means the pod's own termination grace period is used.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=-1
+	GracePeriodSeconds *int `json:"gracePeriodSeconds,omitempty"`
+	// SkipWaitForDeleteTimeoutSeconds skips waiting for pods whose deletion
+	// timestamp is older than the given number of seconds. This is relevant
+	// for NotReady nodes, where pods are never removed by the kubelet.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	SkipWaitForDeleteTimeoutSeconds *int `json:"skipWaitForDeleteTimeoutSeconds,omitempty"`
+	// Timeout is the maximum duration the drain may take before it is
+	// considered failed, e.g. 30m. Zero means no timeout.
+	// +kubebuilder:validation:Optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
 // +k8s:openapi-gen=true
 type DrainerConfigSpecGuest struct {
 	Cluster DrainerConfigSpecGuestCluster `json:"cluster"`
@@ -100,7 +150,7 @@ type DrainerConfigStatusCondition struct {
 	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
 	// Status may be True, False or Unknown.
 	Status string `json:"status"`
-	// Type may be Pending, Ready, Draining, Drained.
+	// Type may be Pending, Cordoned, Draining, Drained, Failed or Timeout.
 	Type string `json:"type"`
 }
 
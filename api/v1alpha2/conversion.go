@@ -0,0 +1,5 @@
+package v1alpha2
+
+// Hub marks v1alpha2 as the version all other DrainerConfig versions convert
+// to and from.
+func (*DrainerConfig) Hub() {}
@@ -0,0 +1,6 @@
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen=package,register
+
+// +groupName=core.giantswarm.io
+// +groupGoName=Core
+package v1alpha2
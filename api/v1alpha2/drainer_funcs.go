@@ -0,0 +1,102 @@
+package v1alpha2
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (s DrainerConfigStatus) HasCordonedCondition() bool {
+	return meta.IsStatusConditionTrue(s.Conditions, ConditionTypeCordoned)
+}
+
+func (s DrainerConfigStatus) HasDrainedCondition() bool {
+	return meta.IsStatusConditionTrue(s.Conditions, ConditionTypeDrained)
+}
+
+func (s DrainerConfigStatus) HasDrainingCondition() bool {
+	return meta.IsStatusConditionTrue(s.Conditions, ConditionTypeDraining)
+}
+
+func (s DrainerConfigStatus) HasEscalatedCondition() bool {
+	return meta.IsStatusConditionTrue(s.Conditions, ConditionTypeEscalated)
+}
+
+func (s DrainerConfigStatus) HasFailedCondition() bool {
+	return meta.IsStatusConditionTrue(s.Conditions, ConditionTypeFailed)
+}
+
+func (s DrainerConfigStatus) HasPendingCondition() bool {
+	return meta.IsStatusConditionTrue(s.Conditions, ConditionTypePending)
+}
+
+func (s DrainerConfigStatus) HasQuarantinedCondition() bool {
+	return meta.IsStatusConditionTrue(s.Conditions, ConditionTypeQuarantined)
+}
+
+func (s DrainerConfigStatus) HasTimeoutCondition() bool {
+	return meta.IsStatusConditionTrue(s.Conditions, ConditionTypeTimeout)
+}
+
+func (s DrainerConfigStatus) NewCordonedCondition(reason, message string) metav1.Condition {
+	return newCondition(ConditionTypeCordoned, metav1.ConditionTrue, reason, message)
+}
+
+func (s DrainerConfigStatus) NewDrainedCondition(reason, message string) metav1.Condition {
+	return newCondition(ConditionTypeDrained, metav1.ConditionTrue, reason, message)
+}
+
+func (s DrainerConfigStatus) NewDrainingCondition(reason, message string) metav1.Condition {
+	return newCondition(ConditionTypeDraining, metav1.ConditionTrue, reason, message)
+}
+
+func (s DrainerConfigStatus) NewEscalatedCondition(reason, message string) metav1.Condition {
+	return newCondition(ConditionTypeEscalated, metav1.ConditionTrue, reason, message)
+}
+
+func (s DrainerConfigStatus) NewFailedCondition(reason, message string) metav1.Condition {
+	return newCondition(ConditionTypeFailed, metav1.ConditionTrue, reason, message)
+}
+
+func (s DrainerConfigStatus) NewPendingCondition(reason, message string) metav1.Condition {
+	return newCondition(ConditionTypePending, metav1.ConditionTrue, reason, message)
+}
+
+func (s DrainerConfigStatus) NewQuarantinedCondition(reason, message string) metav1.Condition {
+	return newCondition(ConditionTypeQuarantined, metav1.ConditionTrue, reason, message)
+}
+
+func (s DrainerConfigStatus) NewTimeoutCondition(reason, message string) metav1.Condition {
+	return newCondition(ConditionTypeTimeout, metav1.ConditionTrue, reason, message)
+}
+
+// IsDrainCanceled returns true if the status tells that the drain got
+// canceled.
+func (s DrainerConfigStatus) IsDrainCanceled() bool {
+	return s.Drain != nil && s.Drain.Phase == DrainPhaseCanceled
+}
+
+// IsDrainInFlight returns true if the status tells that a drain got started
+// but did not finish yet.
+func (s DrainerConfigStatus) IsDrainInFlight() bool {
+	if s.Drain == nil {
+		return false
+	}
+
+	return s.Drain.Phase == DrainPhaseCordoning || s.Drain.Phase == DrainPhaseEvicting || s.Drain.Phase == DrainPhaseDetaching
+}
+
+// SetCondition adds the given condition to the status or updates the existing
+// condition of the same type. LastTransitionTime only changes when the status
+// of the condition changes.
+func (s *DrainerConfigStatus) SetCondition(c metav1.Condition) {
+	meta.SetStatusCondition(&s.Conditions, c)
+}
+
+func newCondition(t string, s metav1.ConditionStatus, reason, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:    t,
+		Status:  s,
+		Reason:  reason,
+		Message: message,
+	}
+}
@@ -0,0 +1,108 @@
+package v1alpha2
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_NewConditions(t *testing.T) {
+	testCases := []struct {
+		name         string
+		newCondition func(DrainerConfigStatus, string, string) metav1.Condition
+		hasCondition func(DrainerConfigStatus) bool
+	}{
+		{
+			name:         "case 0: Pending condition",
+			newCondition: DrainerConfigStatus.NewPendingCondition,
+			hasCondition: DrainerConfigStatus.HasPendingCondition,
+		},
+		{
+			name:         "case 1: Cordoned condition",
+			newCondition: DrainerConfigStatus.NewCordonedCondition,
+			hasCondition: DrainerConfigStatus.HasCordonedCondition,
+		},
+		{
+			name:         "case 2: Draining condition",
+			newCondition: DrainerConfigStatus.NewDrainingCondition,
+			hasCondition: DrainerConfigStatus.HasDrainingCondition,
+		},
+		{
+			name:         "case 3: Drained condition",
+			newCondition: DrainerConfigStatus.NewDrainedCondition,
+			hasCondition: DrainerConfigStatus.HasDrainedCondition,
+		},
+		{
+			name:         "case 4: Failed condition",
+			newCondition: DrainerConfigStatus.NewFailedCondition,
+			hasCondition: DrainerConfigStatus.HasFailedCondition,
+		},
+		{
+			name:         "case 5: Timeout condition",
+			newCondition: DrainerConfigStatus.NewTimeoutCondition,
+			hasCondition: DrainerConfigStatus.HasTimeoutCondition,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			status := DrainerConfigStatus{}
+			if tc.hasCondition(status) {
+				t.Fatalf("empty DrainerConfigStatus has condition")
+			}
+
+			status.SetCondition(tc.newCondition(status, ReasonDrainRequested, "message"))
+			if !tc.hasCondition(status) {
+				t.Fatalf("DrainerConfigStatus doesn't have condition after SetCondition() call")
+			}
+			if status.Conditions[0].Reason != ReasonDrainRequested {
+				t.Fatalf("Reason == %q, expected %q", status.Conditions[0].Reason, ReasonDrainRequested)
+			}
+			if status.Conditions[0].Message != "message" {
+				t.Fatalf("Message == %q, expected %q", status.Conditions[0].Message, "message")
+			}
+		})
+	}
+}
+
+func Test_SetCondition(t *testing.T) {
+	transition := metav1.NewTime(time.Now().Add(-time.Hour))
+
+	status := DrainerConfigStatus{
+		Conditions: []metav1.Condition{
+			{
+				LastTransitionTime: transition,
+				Reason:             ReasonDrainStarted,
+				Status:             metav1.ConditionTrue,
+				Type:               ConditionTypeDraining,
+			},
+		},
+	}
+
+	// Setting a condition with the same status keeps the transition time.
+	status.SetCondition(status.NewDrainingCondition(ReasonDrainStarted, "still draining"))
+	if len(status.Conditions) != 1 {
+		t.Fatalf("expected 1 condition, got %d", len(status.Conditions))
+	}
+	if !status.Conditions[0].LastTransitionTime.Equal(&transition) {
+		t.Fatalf("LastTransitionTime changed although status did not change")
+	}
+	if status.Conditions[0].Message != "still draining" {
+		t.Fatalf("Message was not updated")
+	}
+
+	// Setting a condition with another status replaces it.
+	c := status.NewDrainingCondition(ReasonNodeDrained, "drained")
+	c.Status = metav1.ConditionFalse
+	status.SetCondition(c)
+	if len(status.Conditions) != 1 {
+		t.Fatalf("expected 1 condition, got %d", len(status.Conditions))
+	}
+	if status.HasDrainingCondition() {
+		t.Fatalf("DrainerConfigStatus has Draining condition after setting it to False")
+	}
+	if !status.Conditions[0].LastTransitionTime.After(transition.Time) {
+		t.Fatalf("LastTransitionTime was not updated")
+	}
+}
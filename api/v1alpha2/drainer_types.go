@@ -0,0 +1,565 @@
+package v1alpha2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ConditionTypePending is true from the moment the operator picked up the
+	// DrainerConfig until it starts draining the node.
+	ConditionTypePending = "Pending"
+	// ConditionTypeCordoned is true once the node got cordoned.
+	ConditionTypeCordoned = "Cordoned"
+	// ConditionTypeDraining is true while pods are being evicted from the
+	// node.
+	ConditionTypeDraining = "Draining"
+	// ConditionTypeDrained is true once the node got drained.
+	ConditionTypeDrained = "Drained"
+	// ConditionTypeEscalated is true once the drain escalated from evicting
+	// pods to deleting them, as configured in spec.escalation. Its reason
+	// tells the last escalation.
+	ConditionTypeEscalated = "Escalated"
+	// ConditionTypeFailed is true when the last attempt to cordon or drain the
+	// node failed. Its reason tells why.
+	ConditionTypeFailed = "Failed"
+	// ConditionTypeTimeout is true once the operator gave up draining the
+	// node.
+	ConditionTypeTimeout = "Timeout"
+	// ConditionTypeQuarantined is true once the node got cordoned, and
+	// tainted, for the Cordon and Taint actions.
+	ConditionTypeQuarantined = "Quarantined"
+)
+
+const (
+	// ReasonAPIUnavailable means the workload cluster API could not be
+	// reached.
+	ReasonAPIUnavailable = "APIUnavailable"
+	// ReasonCanceled means the drain got canceled, either by setting
+	// spec.cancel or by deleting the DrainerConfig before the drain finished.
+	ReasonCanceled = "Canceled"
+	// ReasonCordonFailed means the node could not be cordoned.
+	ReasonCordonFailed = "CordonFailed"
+	// ReasonDrainFailed means the drain failed for a reason not covered by
+	// any of the more specific reasons.
+	ReasonDrainFailed = "DrainFailed"
+	// ReasonDrainRequested means the operator picked up the DrainerConfig.
+	ReasonDrainRequested = "DrainRequested"
+	// ReasonDrainStarted means the operator started evicting pods.
+	ReasonDrainStarted = "DrainStarted"
+	// ReasonNodeCordoned means the node got cordoned.
+	ReasonNodeCordoned = "NodeCordoned"
+	// ReasonNodeDeleted means the node got drained and deleted from the
+	// workload cluster.
+	ReasonNodeDeleted = "NodeDeleted"
+	// ReasonNodeDrained means all pods got evicted or deleted from the node.
+	ReasonNodeDrained = "NodeDrained"
+	// ReasonNodeNotFound means the node does not exist in the workload
+	// cluster, e.g. because a spot instance got terminated already.
+	ReasonNodeNotFound = "NodeNotFound"
+	// ReasonNodeQuarantined means the node got cordoned, and tainted, without
+	// evicting any pods.
+	ReasonNodeQuarantined = "NodeQuarantined"
+	// ReasonNodeUnreachable means the node is unreachable, so pods stuck
+	// terminating got deleted with a grace period of zero, as configured in
+	// spec.escalation.forceDeleteOnUnreachableNode.
+	ReasonNodeUnreachable = "NodeUnreachable"
+	// ReasonPodsDeleted means pods left after evicting them for
+	// spec.escalation.deleteAfter got deleted, bypassing
+	// PodDisruptionBudgets.
+	ReasonPodsDeleted = "PodsDeleted"
+	// ReasonPodsForceDeleted means pods stuck terminating got deleted with a
+	// grace period of zero after spec.escalation.forceDeleteAfter.
+	ReasonPodsForceDeleted = "PodsForceDeleted"
+	// ReasonPreflightBlocked means pods on the node keep it from being
+	// drained, and the drain waits for them as requested by
+	// spec.preflight.waitForBlockers. See status.preflight.
+	ReasonPreflightBlocked = "PreflightBlocked"
+	// ReasonPodDisruptionBudgetBlocked means pods left on the node are
+	// protected by PodDisruptionBudgets which do not allow any disruption.
+	ReasonPodDisruptionBudgetBlocked = "PodDisruptionBudgetBlocked"
+	// ReasonRetryRequested means a retry of the drain got requested using
+	// the node-operator.giantswarm.io/retry annotation.
+	ReasonRetryRequested = "RetryRequested"
+	// ReasonRetryScheduled means the drain failed and gets retried after
+	// its backoff, as configured in spec.retry.
+	ReasonRetryScheduled = "RetryScheduled"
+	// ReasonTimeout means the drain did not finish within its timeout.
+	ReasonTimeout = "Timeout"
+	// ReasonVolumeDetachTimeout means volumes were still attached to the
+	// node after spec.volumeDetach.timeout.
+	ReasonVolumeDetachTimeout = "VolumeDetachTimeout"
+	// ReasonWaitingForVolumeDetach means the pods got removed from the node
+	// and the drain waits for its volumes to be detached, as configured in
+	// spec.volumeDetach.
+	ReasonWaitingForVolumeDetach = "WaitingForVolumeDetach"
+)
+
+const (
+	// DrainPhaseCordoning means the node is being cordoned.
+	DrainPhaseCordoning = "Cordoning"
+	// DrainPhaseEvicting means pods are being evicted from the node.
+	DrainPhaseEvicting = "Evicting"
+	// DrainPhaseDetaching means the pods got removed from the node and the
+	// volumes of the node are being detached.
+	DrainPhaseDetaching = "Detaching"
+	// DrainPhaseCompleted means the node got drained.
+	DrainPhaseCompleted = "Completed"
+	// DrainPhaseFailed means the operator gave up draining the node.
+	DrainPhaseFailed = "Failed"
+	// DrainPhaseCanceled means the drain got canceled.
+	DrainPhaseCanceled = "Canceled"
+)
+
+const (
+	// ActionCordon means the node is only cordoned.
+	ActionCordon = "Cordon"
+	// ActionDrain means the node is cordoned and drained. This is the
+	// default.
+	ActionDrain = "Drain"
+	// ActionDrainAndDelete means the node is cordoned, drained and deleted
+	// from the workload cluster once it got drained.
+	ActionDrainAndDelete = "DrainAndDelete"
+	// ActionTaint means the node is cordoned and tainted, without evicting
+	// any pods.
+	ActionTaint = "Taint"
+)
+
+const (
+	// CredentialsTypeCerts means the workload cluster is accessed with
+	// certificates issued for the operator, found by the workload cluster ID.
+	// This is the default unless spec.clusterAPI is set.
+	CredentialsTypeCerts = "Certs"
+	// CredentialsTypeKubeconfig means the workload cluster is accessed with a
+	// kubeconfig stored in a Secret. This is the default if spec.clusterAPI
+	// is set.
+	CredentialsTypeKubeconfig = "Kubeconfig"
+	// CredentialsTypeServiceAccountToken means the workload cluster is
+	// accessed with the token and CA certificate of a service account token
+	// Secret at spec.workloadCluster.api.endpoint.
+	CredentialsTypeServiceAccountToken = "ServiceAccountToken"
+)
+
+const (
+	// OnDeleteDeleteNode means the node gets deleted from the workload
+	// cluster when its DrainerConfig is deleted. This is the default.
+	OnDeleteDeleteNode = "DeleteNode"
+	// OnDeleteLeaveCordoned means the node is left as it is when its
+	// DrainerConfig is deleted.
+	OnDeleteLeaveCordoned = "LeaveCordoned"
+	// OnDeleteUncordonNode means the node gets uncordoned when its
+	// DrainerConfig is deleted, e.g. after an in-place reboot.
+	OnDeleteUncordonNode = "UncordonNode"
+)
+
+const (
+	// UnevictedPodReasonLocalStorage means the pod uses emptyDir volumes.
+	UnevictedPodReasonLocalStorage = "LocalStorage"
+	// UnevictedPodReasonPodDisruptionBudget means the pod is selected by a
+	// PodDisruptionBudget which does not allow any disruption.
+	UnevictedPodReasonPodDisruptionBudget = "PodDisruptionBudget"
+	// UnevictedPodReasonTerminating means the pod got evicted or deleted,
+	// but is stuck terminating.
+	UnevictedPodReasonTerminating = "Terminating"
+	// UnevictedPodReasonUnknown means none of the other reasons applies,
+	// e.g. because the drain timed out before the pod got evicted.
+	UnevictedPodReasonUnknown = "Unknown"
+	// UnevictedPodReasonUnmanaged means the pod is not managed by any
+	// controller.
+	UnevictedPodReasonUnmanaged = "Unmanaged"
+)
+
+const (
+	// RetryAnnotation asks the operator to retry a failed drain. Its value is
+	// ignored. The operator removes it once it reset the drain.
+	RetryAnnotation = "node-operator.giantswarm.io/retry"
+)
+
+const (
+	kindDrainerConfig = "DrainerConfig"
+)
+
+func NewDrainerTypeMeta() metav1.TypeMeta {
+	return metav1.TypeMeta{
+		APIVersion: SchemeGroupVersion.String(),
+		Kind:       kindDrainerConfig,
+	}
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories=common;giantswarm
+// +k8s:openapi-gen=true
+type DrainerConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              DrainerConfigSpec `json:"spec"`
+	// +kubebuilder:validation:Optional
+	Status DrainerConfigStatus `json:"status"`
+}
+
+// +k8s:openapi-gen=true
+type DrainerConfigSpec struct {
+	// Action is what is done to the node. See the Action constants for the
+	// known actions. Defaults to Drain.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Cordon;Drain;DrainAndDelete;Taint
+	Action string `json:"action,omitempty"`
+	// Cancel stops the drain in flight. A canceled drain is not started
+	// again.
+	// +kubebuilder:validation:Optional
+	Cancel bool `json:"cancel,omitempty"`
+	// ClusterAPI makes the operator access the workload cluster using the
+	// kubeconfig Secret of its Cluster API Cluster instead of certificates
+	// issued for the operator, unless spec.workloadCluster.credentials says
+	// otherwise. spec.workloadCluster.api is not needed then.
+	// +kubebuilder:validation:Optional
+	ClusterAPI *DrainerConfigSpecClusterAPI `json:"clusterAPI,omitempty"`
+	// Escalation configures how the drain escalates from evicting pods to
+	// deleting them when pods are left on the node for too long.
+	// +kubebuilder:validation:Optional
+	Escalation *DrainerConfigSpecEscalation `json:"escalation,omitempty"`
+	// Node is the workload cluster node to drain. It is required unless
+	// spec.clusterAPI.machine is set.
+	// +kubebuilder:validation:Optional
+	Node DrainerConfigSpecNode `json:"node"`
+	// OnDelete is what happens to the node when the DrainerConfig is deleted.
+	// See the OnDelete constants for the known actions. Defaults to
+	// DeleteNode.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=DeleteNode;LeaveCordoned;UncordonNode
+	OnDelete string `json:"onDelete,omitempty"`
+	// Policy configures how the node is drained. Unset fields fall back to the
+	// operator defaults for the kind of node being drained.
+	// +kubebuilder:validation:Optional
+	Policy *DrainerConfigSpecPolicy `json:"policy,omitempty"`
+	// Preflight configures the analysis of the pods on the node done before
+	// the node gets cordoned.
+	// +kubebuilder:validation:Optional
+	Preflight *DrainerConfigSpecPreflight `json:"preflight,omitempty"`
+	// Retry configures whether and how failed drains are retried.
+	// +kubebuilder:validation:Optional
+	Retry *DrainerConfigSpecRetry `json:"retry,omitempty"`
+	// Taints are added to the node when it gets cordoned and removed again
+	// when it gets uncordoned. The Taint action falls back to a NoSchedule
+	// taint with the key node-operator.giantswarm.io/quarantined.
+	// +kubebuilder:validation:Optional
+	Taints []corev1.Taint `json:"taints,omitempty"`
+	// UncordonOnCancel defines whether the node gets uncordoned when its drain
+	// is canceled, either by setting Cancel or by deleting the DrainerConfig
+	// before the drain finished. It takes precedence over OnDelete for
+	// DrainerConfigs deleted mid-drain.
+	// +kubebuilder:validation:Optional
+	UncordonOnCancel bool `json:"uncordonOnCancel,omitempty"`
+	// VolumeDetach configures waiting for the volumes of the node to be
+	// detached before the node is reported as drained. The drain does not
+	// wait for volumes if it is unset.
+	// +kubebuilder:validation:Optional
+	VolumeDetach *DrainerConfigSpecVolumeDetach `json:"volumeDetach,omitempty"`
+	// WorkloadCluster is the workload cluster the node belongs to. It is
+	// required unless spec.clusterAPI.machine is set.
+	// +kubebuilder:validation:Optional
+	WorkloadCluster DrainerConfigSpecWorkloadCluster `json:"workloadCluster"`
+}
+
+// DrainerConfigSpecClusterAPI configures draining nodes of Cluster API
+// workload clusters. The kubeconfig of the workload cluster is read from the
+// Secret named after the Cluster with the suffix -kubeconfig, in the namespace
+// of the DrainerConfig.
+// +k8s:openapi-gen=true
+type DrainerConfigSpecClusterAPI struct {
+	// Machine is the name of the Cluster API Machine whose node is drained,
+	// in the namespace of the DrainerConfig. The node is taken from
+	// status.nodeRef of the Machine and the workload cluster from its
+	// spec.clusterName. Otherwise spec.node and spec.workloadCluster.id,
+	// the name of the Cluster, tell the node, e.g. of a MachinePool.
+	// +kubebuilder:validation:Optional
+	Machine string `json:"machine,omitempty"`
+}
+
+// DrainerConfigSpecEscalation configures a staged drain. Pods are evicted,
+// respecting PodDisruptionBudgets, before they are deleted. Durations are
+// measured from the time pods started being evicted in the current attempt.
+// +k8s:openapi-gen=true
+type DrainerConfigSpecEscalation struct {
+	// DeleteAfter is the time pods are evicted before the pods left are
+	// deleted instead, bypassing PodDisruptionBudgets, e.g. 10m. Pods are
+	// never deleted instead of evicted if it is unset.
+	// +kubebuilder:validation:Optional
+	DeleteAfter *metav1.Duration `json:"deleteAfter,omitempty"`
+	// ForceDeleteAfter is the time after which pods stuck terminating are
+	// deleted with a grace period of zero, e.g. 15m. Pods stuck terminating
+	// are waited for until the drain times out if it is unset.
+	// +kubebuilder:validation:Optional
+	ForceDeleteAfter *metav1.Duration `json:"forceDeleteAfter,omitempty"`
+	// ForceDeleteOnUnreachableNode defines whether pods stuck terminating
+	// are deleted with a grace period of zero right away when the node is
+	// unreachable, i.e. its kubelet stopped reporting. The VolumeAttachments
+	// of the node are deleted once it got drained, so that stateful
+	// workloads can reschedule. Only use it for nodes which are known to be
+	// gone, as their pods might still be running.
+	// +kubebuilder:validation:Optional
+	ForceDeleteOnUnreachableNode bool `json:"forceDeleteOnUnreachableNode,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type DrainerConfigSpecNode struct {
+	// Name is the name of the workload cluster node to drain.
+	Name string `json:"name"`
+}
+
+// DrainerConfigSpecPolicy mirrors the options of kubectl drain.
+// +k8s:openapi-gen=true
+type DrainerConfigSpecPolicy struct {
+	// DeleteEmptyDirData defines whether pods using emptyDir volumes are
+	// drained anyway, in which case the data in those volumes is lost.
+	// +kubebuilder:validation:Optional
+	DeleteEmptyDirData *bool `json:"deleteEmptyDirData,omitempty"`
+	// DisableEviction defines whether pods are deleted instead of being
+	// evicted. Deleting pods bypasses PodDisruptionBudgets.
+	// +kubebuilder:validation:Optional
+	DisableEviction *bool `json:"disableEviction,omitempty"`
+	// Force defines whether pods not managed by a controller are drained too.
+	// +kubebuilder:validation:Optional
+	Force *bool `json:"force,omitempty"`
+	// GracePeriodSeconds is the period of time given to each pod to terminate
+	// gracefully. -1 means the pod's own termination grace period is used.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=-1
+	GracePeriodSeconds *int `json:"gracePeriodSeconds,omitempty"`
+	// SkipWaitForDeleteTimeoutSeconds skips waiting for pods whose deletion
+	// timestamp is older than the given number of seconds. This is relevant
+	// for NotReady nodes, where pods are never removed by the kubelet.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	SkipWaitForDeleteTimeoutSeconds *int `json:"skipWaitForDeleteTimeoutSeconds,omitempty"`
+	// Timeout is the maximum duration the drain may take before it is
+	// considered failed, e.g. 30m. Zero means no timeout.
+	// +kubebuilder:validation:Optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type DrainerConfigSpecPreflight struct {
+	// WaitForBlockers defines whether the node is only cordoned once no pod
+	// on it keeps it from being drained. Otherwise blockers are only
+	// reported. The status is only written when the preflight report
+	// changes, so while the same pods keep blocking the drain the node is
+	// only checked again with the resync period of the operator.
+	// +kubebuilder:validation:Optional
+	WaitForBlockers bool `json:"waitForBlockers,omitempty"`
+}
+
+// DrainerConfigSpecRetry configures retrying failed drains. Every attempt gets
+// the full drain timeout.
+// +k8s:openapi-gen=true
+type DrainerConfigSpecRetry struct {
+	// Backoff is the time waited before the first retry, e.g. 1m. It doubles
+	// with every further failed attempt, up to one hour. Defaults to one
+	// minute.
+	// +kubebuilder:validation:Optional
+	Backoff *metav1.Duration `json:"backoff,omitempty"`
+	// EscalateToDeleteAfter is the number of failed attempts after which
+	// pods are deleted instead of evicted, bypassing PodDisruptionBudgets.
+	// Zero means pods are never deleted because of failed attempts.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	EscalateToDeleteAfter int `json:"escalateToDeleteAfter,omitempty"`
+	// MaxAttempts is the maximum number of attempts to drain the node,
+	// including the first one. Defaults to 1, which means failed drains are
+	// not retried.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	MaxAttempts int `json:"maxAttempts,omitempty"`
+}
+
+// DrainerConfigSpecVolumeDetach configures waiting for the VolumeAttachments
+// of the node to be gone once its pods got removed, so that stateful workloads
+// can attach their volumes elsewhere before the node is deleted.
+// +k8s:openapi-gen=true
+type DrainerConfigSpecVolumeDetach struct {
+	// Timeout is the maximum duration waited for the volumes to be detached,
+	// e.g. 5m. It is not part of the drain timeout. Defaults to five
+	// minutes.
+	// +kubebuilder:validation:Optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type DrainerConfigSpecWorkloadCluster struct {
+	// API is how the workload cluster is reached. It is required unless the
+	// workload cluster is accessed with a kubeconfig.
+	// +kubebuilder:validation:Optional
+	API DrainerConfigSpecWorkloadClusterAPI `json:"api"`
+	// Credentials configures how the operator authenticates against the
+	// workload cluster.
+	// +kubebuilder:validation:Optional
+	Credentials *DrainerConfigSpecWorkloadClusterCredentials `json:"credentials,omitempty"`
+	// ID is the ID of the workload cluster, which is the name of the Cluster
+	// for Cluster API workload clusters.
+	ID string `json:"id"`
+}
+
+// +k8s:openapi-gen=true
+type DrainerConfigSpecWorkloadClusterAPI struct {
+	// Endpoint is the workload cluster API endpoint.
+	Endpoint string `json:"endpoint"`
+}
+
+// +k8s:openapi-gen=true
+type DrainerConfigSpecWorkloadClusterCredentials struct {
+	// Type is the kind of credentials used. See the CredentialsType constants
+	// for the known types. Defaults to Kubeconfig if spec.clusterAPI is set
+	// and to Certs otherwise.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Certs;Kubeconfig;ServiceAccountToken
+	Type string `json:"type,omitempty"`
+	// Secret is the Secret holding the credentials, in the namespace of the
+	// DrainerConfig. It is required for ServiceAccountToken credentials.
+	// Kubeconfig credentials default to the kubeconfig Secret of the Cluster
+	// API Cluster.
+	// +kubebuilder:validation:Optional
+	Secret *DrainerConfigSpecWorkloadClusterCredentialsSecret `json:"secret,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type DrainerConfigSpecWorkloadClusterCredentialsSecret struct {
+	// Name is the name of the Secret.
+	Name string `json:"name"`
+	// Key is the key of the kubeconfig in the Secret. Defaults to value,
+	// the key Cluster API uses. Service account token Secrets are read from
+	// their token and ca.crt keys.
+	// +kubebuilder:validation:Optional
+	Key string `json:"key,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type DrainerConfigStatus struct {
+	// Conditions describe the lifecycle of the drain. See the ConditionType
+	// constants for the known types.
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Drain is the progress of the drain. It is persisted so that a restarted
+	// operator resumes drains in flight.
+	// +kubebuilder:validation:Optional
+	Drain *DrainerConfigStatusDrain `json:"drain,omitempty"`
+	// LastHeartbeatTime is the last time the operator reported on a drain in
+	// flight.
+	// +kubebuilder:validation:Optional
+	LastHeartbeatTime *metav1.Time `json:"lastHeartbeatTime,omitempty"`
+	// ObservedGeneration is the generation of the DrainerConfig the status was
+	// last computed for.
+	// +kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Preflight is the analysis of the pods on the node done before the node
+	// got cordoned.
+	// +kubebuilder:validation:Optional
+	Preflight *DrainerConfigStatusPreflight `json:"preflight,omitempty"`
+	// UnevictedPods are the pods left on the node by the last failed drain,
+	// limited to the first 20 pods.
+	// +kubebuilder:validation:Optional
+	UnevictedPods []DrainerConfigStatusPod `json:"unevictedPods,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type DrainerConfigStatusDrain struct {
+	// Attempt is the number of times the operator started draining the node,
+	// including drains resumed after an operator restart.
+	Attempt int `json:"attempt"`
+	// Failures is the number of attempts which failed. It decides whether a
+	// failed drain gets retried, as configured in spec.retry.
+	// +kubebuilder:validation:Optional
+	Failures int `json:"failures,omitempty"`
+	// Phase is the phase of the drain. See the DrainPhase constants for the
+	// known phases.
+	Phase string `json:"phase"`
+	// Progress is the progress of evicting the pods from the node in the
+	// current attempt. It is updated periodically while pods are evicted.
+	// +kubebuilder:validation:Optional
+	Progress *DrainerConfigStatusDrainProgress `json:"progress,omitempty"`
+	// RetryTime is the time the failed drain gets retried.
+	// +kubebuilder:validation:Optional
+	RetryTime *metav1.Time `json:"retryTime,omitempty"`
+	// StartTime is the time the current attempt to drain the node started,
+	// including attempts resumed after an operator restart. The drain timeout
+	// is measured from here.
+	StartTime metav1.Time `json:"startTime"`
+}
+
+// DrainerConfigStatusDrainProgress counts the pods removed from the node.
+// Drains which stall can be told apart by LastProgressTime.
+// +k8s:openapi-gen=true
+type DrainerConfigStatusDrainProgress struct {
+	// Deleted is the number of pods deleted from the node, e.g. because
+	// eviction is disabled.
+	Deleted int `json:"deleted"`
+	// Evicted is the number of pods evicted from the node.
+	Evicted int `json:"evicted"`
+	// LastProgressTime is the last time a pod got evicted or deleted, or the
+	// time pods started being evicted if none was yet.
+	LastProgressTime metav1.Time `json:"lastProgressTime"`
+	// Remaining is the number of pods still to be evicted or deleted.
+	Remaining int `json:"remaining"`
+	// StartTime is the time pods started being evicted in the current
+	// attempt.
+	StartTime metav1.Time `json:"startTime"`
+	// Total is the number of pods to be evicted or deleted when the current
+	// attempt started.
+	Total int `json:"total"`
+}
+
+// DrainerConfigStatusPod is a pod left on the node by a failed drain.
+// +k8s:openapi-gen=true
+type DrainerConfigStatusPod struct {
+	// Name is the name of the pod.
+	Name string `json:"name"`
+	// Namespace is the namespace of the pod.
+	Namespace string `json:"namespace"`
+	// OwnerKind is the kind of the controller managing the pod, e.g.
+	// ReplicaSet. It is empty for unmanaged pods.
+	// +kubebuilder:validation:Optional
+	OwnerKind string `json:"ownerKind,omitempty"`
+	// Reason tells why the pod is presumably left on the node. See the
+	// UnevictedPodReason constants for the known reasons.
+	Reason string `json:"reason"`
+}
+
+// DrainerConfigStatusPreflight lists the pods on the node which need special
+// treatment to be drained, by namespace and name. Every list is limited to
+// the first 20 pods.
+// +k8s:openapi-gen=true
+type DrainerConfigStatusPreflight struct {
+	// Blocked is true if any of the listed pods keeps the node from being
+	// drained with the drain policy of the DrainerConfig.
+	Blocked bool `json:"blocked"`
+	// DaemonSetPods are managed by DaemonSets. They are left on the node and
+	// never block the drain.
+	// +kubebuilder:validation:Optional
+	DaemonSetPods []string `json:"daemonSetPods,omitempty"`
+	// LocalStoragePods use emptyDir volumes. They block the drain unless
+	// the policy allows deleting emptyDir data.
+	// +kubebuilder:validation:Optional
+	LocalStoragePods []string `json:"localStoragePods,omitempty"`
+	// PodDisruptionBudgetBlockedPods are selected by PodDisruptionBudgets
+	// which do not allow any disruption. They block the drain unless the
+	// policy disables eviction.
+	// +kubebuilder:validation:Optional
+	PodDisruptionBudgetBlockedPods []string `json:"podDisruptionBudgetBlockedPods,omitempty"`
+	// Time is the time the result of the analysis last changed.
+	Time metav1.Time `json:"time"`
+	// UnmanagedPods are not managed by any controller. They block the drain
+	// unless the policy forces it.
+	// +kubebuilder:validation:Optional
+	UnmanagedPods []string `json:"unmanagedPods,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type DrainerConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []DrainerConfig `json:"items"`
+}
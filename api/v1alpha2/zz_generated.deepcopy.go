@@ -0,0 +1,501 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+/*
+Copyright 2026 Giant Swarm GmbH.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1alpha2
+
+import (
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainerConfig) DeepCopyInto(out *DrainerConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainerConfig.
+func (in *DrainerConfig) DeepCopy() *DrainerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DrainerConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainerConfigList) DeepCopyInto(out *DrainerConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DrainerConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainerConfigList.
+func (in *DrainerConfigList) DeepCopy() *DrainerConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainerConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DrainerConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainerConfigSpec) DeepCopyInto(out *DrainerConfigSpec) {
+	*out = *in
+	if in.ClusterAPI != nil {
+		in, out := &in.ClusterAPI, &out.ClusterAPI
+		*out = new(DrainerConfigSpecClusterAPI)
+		**out = **in
+	}
+	if in.Escalation != nil {
+		in, out := &in.Escalation, &out.Escalation
+		*out = new(DrainerConfigSpecEscalation)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Node = in.Node
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(DrainerConfigSpecPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Preflight != nil {
+		in, out := &in.Preflight, &out.Preflight
+		*out = new(DrainerConfigSpecPreflight)
+		**out = **in
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(DrainerConfigSpecRetry)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Taints != nil {
+		in, out := &in.Taints, &out.Taints
+		*out = make([]v1.Taint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeDetach != nil {
+		in, out := &in.VolumeDetach, &out.VolumeDetach
+		*out = new(DrainerConfigSpecVolumeDetach)
+		(*in).DeepCopyInto(*out)
+	}
+	in.WorkloadCluster.DeepCopyInto(&out.WorkloadCluster)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainerConfigSpec.
+func (in *DrainerConfigSpec) DeepCopy() *DrainerConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainerConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainerConfigSpecClusterAPI) DeepCopyInto(out *DrainerConfigSpecClusterAPI) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainerConfigSpecClusterAPI.
+func (in *DrainerConfigSpecClusterAPI) DeepCopy() *DrainerConfigSpecClusterAPI {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainerConfigSpecClusterAPI)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainerConfigSpecEscalation) DeepCopyInto(out *DrainerConfigSpecEscalation) {
+	*out = *in
+	if in.DeleteAfter != nil {
+		in, out := &in.DeleteAfter, &out.DeleteAfter
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ForceDeleteAfter != nil {
+		in, out := &in.ForceDeleteAfter, &out.ForceDeleteAfter
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainerConfigSpecEscalation.
+func (in *DrainerConfigSpecEscalation) DeepCopy() *DrainerConfigSpecEscalation {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainerConfigSpecEscalation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainerConfigSpecNode) DeepCopyInto(out *DrainerConfigSpecNode) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainerConfigSpecNode.
+func (in *DrainerConfigSpecNode) DeepCopy() *DrainerConfigSpecNode {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainerConfigSpecNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainerConfigSpecPolicy) DeepCopyInto(out *DrainerConfigSpecPolicy) {
+	*out = *in
+	if in.DeleteEmptyDirData != nil {
+		in, out := &in.DeleteEmptyDirData, &out.DeleteEmptyDirData
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DisableEviction != nil {
+		in, out := &in.DisableEviction, &out.DisableEviction
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Force != nil {
+		in, out := &in.Force, &out.Force
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GracePeriodSeconds != nil {
+		in, out := &in.GracePeriodSeconds, &out.GracePeriodSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.SkipWaitForDeleteTimeoutSeconds != nil {
+		in, out := &in.SkipWaitForDeleteTimeoutSeconds, &out.SkipWaitForDeleteTimeoutSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainerConfigSpecPolicy.
+func (in *DrainerConfigSpecPolicy) DeepCopy() *DrainerConfigSpecPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainerConfigSpecPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainerConfigSpecPreflight) DeepCopyInto(out *DrainerConfigSpecPreflight) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainerConfigSpecPreflight.
+func (in *DrainerConfigSpecPreflight) DeepCopy() *DrainerConfigSpecPreflight {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainerConfigSpecPreflight)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainerConfigSpecRetry) DeepCopyInto(out *DrainerConfigSpecRetry) {
+	*out = *in
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainerConfigSpecRetry.
+func (in *DrainerConfigSpecRetry) DeepCopy() *DrainerConfigSpecRetry {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainerConfigSpecRetry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainerConfigSpecVolumeDetach) DeepCopyInto(out *DrainerConfigSpecVolumeDetach) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainerConfigSpecVolumeDetach.
+func (in *DrainerConfigSpecVolumeDetach) DeepCopy() *DrainerConfigSpecVolumeDetach {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainerConfigSpecVolumeDetach)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainerConfigSpecWorkloadCluster) DeepCopyInto(out *DrainerConfigSpecWorkloadCluster) {
+	*out = *in
+	out.API = in.API
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(DrainerConfigSpecWorkloadClusterCredentials)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainerConfigSpecWorkloadCluster.
+func (in *DrainerConfigSpecWorkloadCluster) DeepCopy() *DrainerConfigSpecWorkloadCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainerConfigSpecWorkloadCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainerConfigSpecWorkloadClusterAPI) DeepCopyInto(out *DrainerConfigSpecWorkloadClusterAPI) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainerConfigSpecWorkloadClusterAPI.
+func (in *DrainerConfigSpecWorkloadClusterAPI) DeepCopy() *DrainerConfigSpecWorkloadClusterAPI {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainerConfigSpecWorkloadClusterAPI)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainerConfigSpecWorkloadClusterCredentials) DeepCopyInto(out *DrainerConfigSpecWorkloadClusterCredentials) {
+	*out = *in
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(DrainerConfigSpecWorkloadClusterCredentialsSecret)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainerConfigSpecWorkloadClusterCredentials.
+func (in *DrainerConfigSpecWorkloadClusterCredentials) DeepCopy() *DrainerConfigSpecWorkloadClusterCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainerConfigSpecWorkloadClusterCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainerConfigSpecWorkloadClusterCredentialsSecret) DeepCopyInto(out *DrainerConfigSpecWorkloadClusterCredentialsSecret) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainerConfigSpecWorkloadClusterCredentialsSecret.
+func (in *DrainerConfigSpecWorkloadClusterCredentialsSecret) DeepCopy() *DrainerConfigSpecWorkloadClusterCredentialsSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainerConfigSpecWorkloadClusterCredentialsSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainerConfigStatus) DeepCopyInto(out *DrainerConfigStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Drain != nil {
+		in, out := &in.Drain, &out.Drain
+		*out = new(DrainerConfigStatusDrain)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastHeartbeatTime != nil {
+		in, out := &in.LastHeartbeatTime, &out.LastHeartbeatTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Preflight != nil {
+		in, out := &in.Preflight, &out.Preflight
+		*out = new(DrainerConfigStatusPreflight)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UnevictedPods != nil {
+		in, out := &in.UnevictedPods, &out.UnevictedPods
+		*out = make([]DrainerConfigStatusPod, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainerConfigStatus.
+func (in *DrainerConfigStatus) DeepCopy() *DrainerConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainerConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainerConfigStatusDrain) DeepCopyInto(out *DrainerConfigStatusDrain) {
+	*out = *in
+	if in.Progress != nil {
+		in, out := &in.Progress, &out.Progress
+		*out = new(DrainerConfigStatusDrainProgress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RetryTime != nil {
+		in, out := &in.RetryTime, &out.RetryTime
+		*out = (*in).DeepCopy()
+	}
+	in.StartTime.DeepCopyInto(&out.StartTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainerConfigStatusDrain.
+func (in *DrainerConfigStatusDrain) DeepCopy() *DrainerConfigStatusDrain {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainerConfigStatusDrain)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainerConfigStatusDrainProgress) DeepCopyInto(out *DrainerConfigStatusDrainProgress) {
+	*out = *in
+	in.LastProgressTime.DeepCopyInto(&out.LastProgressTime)
+	in.StartTime.DeepCopyInto(&out.StartTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainerConfigStatusDrainProgress.
+func (in *DrainerConfigStatusDrainProgress) DeepCopy() *DrainerConfigStatusDrainProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainerConfigStatusDrainProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainerConfigStatusPod) DeepCopyInto(out *DrainerConfigStatusPod) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainerConfigStatusPod.
+func (in *DrainerConfigStatusPod) DeepCopy() *DrainerConfigStatusPod {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainerConfigStatusPod)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainerConfigStatusPreflight) DeepCopyInto(out *DrainerConfigStatusPreflight) {
+	*out = *in
+	if in.DaemonSetPods != nil {
+		in, out := &in.DaemonSetPods, &out.DaemonSetPods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LocalStoragePods != nil {
+		in, out := &in.LocalStoragePods, &out.LocalStoragePods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodDisruptionBudgetBlockedPods != nil {
+		in, out := &in.PodDisruptionBudgetBlockedPods, &out.PodDisruptionBudgetBlockedPods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Time.DeepCopyInto(&out.Time)
+	if in.UnmanagedPods != nil {
+		in, out := &in.UnmanagedPods, &out.UnmanagedPods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainerConfigStatusPreflight.
+func (in *DrainerConfigStatusPreflight) DeepCopy() *DrainerConfigStatusPreflight {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainerConfigStatusPreflight)
+	in.DeepCopyInto(out)
+	return out
+}
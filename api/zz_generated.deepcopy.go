@@ -22,6 +22,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -30,7 +31,7 @@ func (in *DrainerConfig) DeepCopyInto(out *DrainerConfig) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -87,6 +88,11 @@ func (in *DrainerConfigList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DrainerConfigSpec) DeepCopyInto(out *DrainerConfigSpec) {
 	*out = *in
+	if in.DrainPolicy != nil {
+		in, out := &in.DrainPolicy, &out.DrainPolicy
+		*out = new(DrainerConfigSpecDrainPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	out.Guest = in.Guest
 	out.VersionBundle = in.VersionBundle
 }
@@ -101,6 +107,51 @@ func (in *DrainerConfigSpec) DeepCopy() *DrainerConfigSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainerConfigSpecDrainPolicy) DeepCopyInto(out *DrainerConfigSpecDrainPolicy) {
+	*out = *in
+	if in.DeleteEmptyDirData != nil {
+		in, out := &in.DeleteEmptyDirData, &out.DeleteEmptyDirData
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DisableEviction != nil {
+		in, out := &in.DisableEviction, &out.DisableEviction
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Force != nil {
+		in, out := &in.Force, &out.Force
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GracePeriodSeconds != nil {
+		in, out := &in.GracePeriodSeconds, &out.GracePeriodSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.SkipWaitForDeleteTimeoutSeconds != nil {
+		in, out := &in.SkipWaitForDeleteTimeoutSeconds, &out.SkipWaitForDeleteTimeoutSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainerConfigSpecDrainPolicy.
+func (in *DrainerConfigSpecDrainPolicy) DeepCopy() *DrainerConfigSpecDrainPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainerConfigSpecDrainPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DrainerConfigSpecGuest) DeepCopyInto(out *DrainerConfigSpecGuest) {
 	*out = *in
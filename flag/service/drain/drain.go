@@ -0,0 +1,27 @@
+package drain
+
+// Drain is a data structure to hold the default drain policies applied to
+// nodes whose DrainerConfig does not specify its own drain policy.
+type Drain struct {
+	ControlPlane Policy
+	Executor     Executor
+	Worker       Policy
+}
+
+// Executor is a data structure to hold the limits of the drain executor.
+type Executor struct {
+	ClusterWorkers string
+	QueueSize      string
+	Workers        string
+}
+
+// Policy is a data structure to hold the drain parameters of one kind of
+// node.
+type Policy struct {
+	DeleteEmptyDirData              string
+	DisableEviction                 string
+	Force                           string
+	GracePeriodSeconds              string
+	SkipWaitForDeleteTimeoutSeconds string
+	Timeout                         string
+}
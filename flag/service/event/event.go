@@ -0,0 +1,7 @@
+package event
+
+// Event is a data structure to hold the configuration of the events recorded
+// about drains.
+type Event struct {
+	Target string
+}
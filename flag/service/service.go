@@ -2,8 +2,18 @@ package service
 
 import (
 	"github.com/giantswarm/operatorkit/v7/pkg/flag/service/kubernetes"
+
+	"github.com/giantswarm/node-operator/flag/service/drain"
+	"github.com/giantswarm/node-operator/flag/service/event"
+	"github.com/giantswarm/node-operator/flag/service/webhook"
+	"github.com/giantswarm/node-operator/flag/service/workloadcluster"
 )
 
 type Service struct {
+	Drain      drain.Drain
+	Event      event.Event
 	Kubernetes kubernetes.Kubernetes
+	Webhook    webhook.Webhook
+
+	WorkloadCluster workloadcluster.WorkloadCluster
 }
@@ -0,0 +1,30 @@
+package webhook
+
+// Webhook is a data structure to hold the configuration of the HTTPS listener
+// serving the webhooks called by the Kubernetes API server.
+type Webhook struct {
+	Listen  Listen
+	Service Service
+	TLS     TLS
+}
+
+// Listen is a data structure to hold the address the webhooks are served at.
+type Listen struct {
+	Address string
+}
+
+// Service is a data structure to hold the Kubernetes Service the Kubernetes
+// API server reaches the webhooks through.
+type Service struct {
+	Name      string
+	Namespace string
+	Port      string
+}
+
+// TLS is a data structure to hold the certificate files the webhooks are
+// served with.
+type TLS struct {
+	CAFile  string
+	CrtFile string
+	KeyFile string
+}
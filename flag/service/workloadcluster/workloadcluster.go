@@ -0,0 +1,13 @@
+package workloadcluster
+
+// WorkloadCluster is a data structure to hold the configuration of the access
+// to workload clusters.
+type WorkloadCluster struct {
+	ClientCache ClientCache
+}
+
+// ClientCache is a data structure to hold the configuration of the cache of
+// workload cluster clients.
+type ClientCache struct {
+	TTL string
+}
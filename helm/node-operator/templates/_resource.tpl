@@ -20,6 +20,10 @@ room for such suffix.
 {{- include "resource.default.name" . -}}-psp
 {{- end -}}
 
+{{- define "resource.webhook.name" -}}
+{{- include "resource.default.name" . -}}-webhook
+{{- end -}}
+
 {{- define "resource.default.namespace" -}}
 {{ .Release.Namespace }}
 {{- end -}}
@@ -1,6 +1,11 @@
 package main
 
 import (
+	"context"
+	"os/signal"
+	"syscall"
+	"time"
+
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/microkit/command"
 	microserver "github.com/giantswarm/microkit/server"
@@ -54,12 +59,24 @@ func mainE() error {
 				panic(microerror.JSON(err))
 			}
 			go newService.Boot()
+
+			// The microkit daemon exits on SIGINT and SIGTERM after shutting
+			// down its server, without telling the service. We shut the
+			// service down on the same signals, so that drains in flight get
+			// canceled instead of being cut off.
+			ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+			go func() {
+				<-ctx.Done()
+				stop()
+				newService.Shutdown()
+			}()
 		}
 
 		// Create a new custom server which bundles our endpoints.
 		var newServer microserver.Server
 		{
 			c := server.Config{
+				Flag:    f,
 				Logger:  logger,
 				Service: newService,
 				Viper:   v,
@@ -96,12 +113,36 @@ func mainE() error {
 
 	daemonCommand := newCommand.DaemonCommand().CobraCommand()
 
+	daemonCommand.PersistentFlags().Bool(f.Service.Drain.ControlPlane.DeleteEmptyDirData, true, "Whether to drain control plane pods using emptyDir volumes, losing their data.")
+	daemonCommand.PersistentFlags().Bool(f.Service.Drain.ControlPlane.DisableEviction, false, "Whether to delete control plane pods instead of evicting them, bypassing PodDisruptionBudgets.")
+	daemonCommand.PersistentFlags().Bool(f.Service.Drain.ControlPlane.Force, true, "Whether to drain control plane pods not managed by a controller.")
+	daemonCommand.PersistentFlags().Int(f.Service.Drain.ControlPlane.GracePeriodSeconds, 45, "Termination grace period given to control plane pods. -1 uses the pod's own grace period.")
+	daemonCommand.PersistentFlags().Int(f.Service.Drain.ControlPlane.SkipWaitForDeleteTimeoutSeconds, 15, "Seconds after which control plane pods being deleted are not waited for anymore.")
+	daemonCommand.PersistentFlags().Duration(f.Service.Drain.ControlPlane.Timeout, 2*time.Minute, "Maximum duration of a control plane node drain. Zero means no timeout.")
+	daemonCommand.PersistentFlags().Int(f.Service.Drain.Executor.ClusterWorkers, 5, "Maximum number of nodes drained at the same time per workload cluster.")
+	daemonCommand.PersistentFlags().Int(f.Service.Drain.Executor.QueueSize, 1000, "Maximum number of drains waiting to be started.")
+	daemonCommand.PersistentFlags().Int(f.Service.Drain.Executor.Workers, 20, "Maximum number of nodes drained at the same time.")
+	daemonCommand.PersistentFlags().Bool(f.Service.Drain.Worker.DeleteEmptyDirData, true, "Whether to drain worker pods using emptyDir volumes, losing their data.")
+	daemonCommand.PersistentFlags().Bool(f.Service.Drain.Worker.DisableEviction, false, "Whether to delete worker pods instead of evicting them, bypassing PodDisruptionBudgets.")
+	daemonCommand.PersistentFlags().Bool(f.Service.Drain.Worker.Force, true, "Whether to drain worker pods not managed by a controller.")
+	daemonCommand.PersistentFlags().Int(f.Service.Drain.Worker.GracePeriodSeconds, 60, "Termination grace period given to worker pods. -1 uses the pod's own grace period.")
+	daemonCommand.PersistentFlags().Int(f.Service.Drain.Worker.SkipWaitForDeleteTimeoutSeconds, 15, "Seconds after which worker pods being deleted are not waited for anymore.")
+	daemonCommand.PersistentFlags().Duration(f.Service.Drain.Worker.Timeout, 5*time.Minute, "Maximum duration of a worker node drain. Zero means no timeout.")
+	daemonCommand.PersistentFlags().String(f.Service.Event.Target, "Auto", "Object events about drains are recorded for: AWSCluster, Cluster, DrainerConfig or None. Auto uses the first of the AWSCluster, the Cluster API Cluster and the DrainerConfig which exists.")
 	daemonCommand.PersistentFlags().String(f.Service.Kubernetes.Address, "http://127.0.0.1:6443", "Address used to connect to Kubernetes. When empty in-cluster config is created.")
 	daemonCommand.PersistentFlags().Bool(f.Service.Kubernetes.InCluster, false, "Whether to use the in-cluster config to authenticate with Kubernetes.")
 	daemonCommand.PersistentFlags().String(f.Service.Kubernetes.KubeConfig, "", "KubeConfig used to connect to Kubernetes. When empty other settings are used.")
 	daemonCommand.PersistentFlags().String(f.Service.Kubernetes.TLS.CAFile, "", "Certificate authority file path to use to authenticate with Kubernetes.")
 	daemonCommand.PersistentFlags().String(f.Service.Kubernetes.TLS.CrtFile, "", "Certificate file path to use to authenticate with Kubernetes.")
 	daemonCommand.PersistentFlags().String(f.Service.Kubernetes.TLS.KeyFile, "", "Key file path to use to authenticate with Kubernetes.")
+	daemonCommand.PersistentFlags().String(f.Service.Webhook.Listen.Address, "", "Address the webhooks are served at using HTTPS, e.g. 0.0.0.0:8443. When set, the server listens here using HTTPS instead of at server.listen.address, so /metrics is best moved to server.listen.metricsaddress. When empty the webhooks are not served.")
+	daemonCommand.PersistentFlags().String(f.Service.Webhook.Service.Name, "", "Name of the Service the Kubernetes API server reaches the webhooks through. When empty the DrainerConfig CRD conversion is not configured.")
+	daemonCommand.PersistentFlags().String(f.Service.Webhook.Service.Namespace, "", "Namespace of the Service the Kubernetes API server reaches the webhooks through.")
+	daemonCommand.PersistentFlags().Int(f.Service.Webhook.Service.Port, 443, "Port of the Service the Kubernetes API server reaches the webhooks through.")
+	daemonCommand.PersistentFlags().String(f.Service.Webhook.TLS.CAFile, "", "Certificate authority file path the Kubernetes API server verifies the webhooks with.")
+	daemonCommand.PersistentFlags().String(f.Service.Webhook.TLS.CrtFile, "", "Certificate file path to serve the webhooks with.")
+	daemonCommand.PersistentFlags().String(f.Service.Webhook.TLS.KeyFile, "", "Key file path to serve the webhooks with.")
+	daemonCommand.PersistentFlags().Duration(f.Service.WorkloadCluster.ClientCache.TTL, 10*time.Minute, "Maximum amount of time a workload cluster client is reused. Zero disables caching.")
 
 	err = newCommand.CobraCommand().Execute()
 	if err != nil {
@@ -0,0 +1,70 @@
+/*
+Copyright 2026 Giant Swarm GmbH.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha2
+
+import (
+	context "context"
+
+	apiv1alpha2 "github.com/giantswarm/node-operator/api/v1alpha2"
+	scheme "github.com/giantswarm/node-operator/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// DrainerConfigsGetter has a method to return a DrainerConfigInterface.
+// A group's client should implement this interface.
+type DrainerConfigsGetter interface {
+	DrainerConfigs(namespace string) DrainerConfigInterface
+}
+
+// DrainerConfigInterface has methods to work with DrainerConfig resources.
+type DrainerConfigInterface interface {
+	Create(ctx context.Context, drainerConfig *apiv1alpha2.DrainerConfig, opts v1.CreateOptions) (*apiv1alpha2.DrainerConfig, error)
+	Update(ctx context.Context, drainerConfig *apiv1alpha2.DrainerConfig, opts v1.UpdateOptions) (*apiv1alpha2.DrainerConfig, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, drainerConfig *apiv1alpha2.DrainerConfig, opts v1.UpdateOptions) (*apiv1alpha2.DrainerConfig, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*apiv1alpha2.DrainerConfig, error)
+	List(ctx context.Context, opts v1.ListOptions) (*apiv1alpha2.DrainerConfigList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *apiv1alpha2.DrainerConfig, err error)
+	DrainerConfigExpansion
+}
+
+// drainerConfigs implements DrainerConfigInterface
+type drainerConfigs struct {
+	*gentype.ClientWithList[*apiv1alpha2.DrainerConfig, *apiv1alpha2.DrainerConfigList]
+}
+
+// newDrainerConfigs returns a DrainerConfigs
+func newDrainerConfigs(c *CoreV1alpha2Client, namespace string) *drainerConfigs {
+	return &drainerConfigs{
+		gentype.NewClientWithList[*apiv1alpha2.DrainerConfig, *apiv1alpha2.DrainerConfigList](
+			"drainerconfigs",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *apiv1alpha2.DrainerConfig { return &apiv1alpha2.DrainerConfig{} },
+			func() *apiv1alpha2.DrainerConfigList { return &apiv1alpha2.DrainerConfigList{} },
+		),
+	}
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2026 Giant Swarm GmbH.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha2 "github.com/giantswarm/node-operator/api/v1alpha2"
+	apiv1alpha2 "github.com/giantswarm/node-operator/pkg/client/clientset/versioned/typed/api/v1alpha2"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeDrainerConfigs implements DrainerConfigInterface
+type fakeDrainerConfigs struct {
+	*gentype.FakeClientWithList[*v1alpha2.DrainerConfig, *v1alpha2.DrainerConfigList]
+	Fake *FakeCoreV1alpha2
+}
+
+func newFakeDrainerConfigs(fake *FakeCoreV1alpha2, namespace string) apiv1alpha2.DrainerConfigInterface {
+	return &fakeDrainerConfigs{
+		gentype.NewFakeClientWithList[*v1alpha2.DrainerConfig, *v1alpha2.DrainerConfigList](
+			fake.Fake,
+			namespace,
+			v1alpha2.SchemeGroupVersion.WithResource("drainerconfigs"),
+			v1alpha2.SchemeGroupVersion.WithKind("DrainerConfig"),
+			func() *v1alpha2.DrainerConfig { return &v1alpha2.DrainerConfig{} },
+			func() *v1alpha2.DrainerConfigList { return &v1alpha2.DrainerConfigList{} },
+			func(dst, src *v1alpha2.DrainerConfigList) { dst.ListMeta = src.ListMeta },
+			func(list *v1alpha2.DrainerConfigList) []*v1alpha2.DrainerConfig {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1alpha2.DrainerConfigList, items []*v1alpha2.DrainerConfig) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2026 Giant Swarm GmbH.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha2
+
+import (
+	context "context"
+	time "time"
+
+	nodeoperatorapiv1alpha2 "github.com/giantswarm/node-operator/api/v1alpha2"
+	versioned "github.com/giantswarm/node-operator/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/giantswarm/node-operator/pkg/client/informers/externalversions/internalinterfaces"
+	apiv1alpha2 "github.com/giantswarm/node-operator/pkg/client/listers/api/v1alpha2"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// DrainerConfigInformer provides access to a shared informer and lister for
+// DrainerConfigs.
+type DrainerConfigInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() apiv1alpha2.DrainerConfigLister
+}
+
+type drainerConfigInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewDrainerConfigInformer constructs a new informer for DrainerConfig type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewDrainerConfigInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredDrainerConfigInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredDrainerConfigInformer constructs a new informer for DrainerConfig type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredDrainerConfigInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.CoreV1alpha2().DrainerConfigs(namespace).List(context.Background(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.CoreV1alpha2().DrainerConfigs(namespace).Watch(context.Background(), options)
+			},
+			ListWithContextFunc: func(ctx context.Context, options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.CoreV1alpha2().DrainerConfigs(namespace).List(ctx, options)
+			},
+			WatchFuncWithContext: func(ctx context.Context, options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.CoreV1alpha2().DrainerConfigs(namespace).Watch(ctx, options)
+			},
+		},
+		&nodeoperatorapiv1alpha2.DrainerConfig{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *drainerConfigInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredDrainerConfigInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *drainerConfigInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&nodeoperatorapiv1alpha2.DrainerConfig{}, f.defaultInformer)
+}
+
+func (f *drainerConfigInformer) Lister() apiv1alpha2.DrainerConfigLister {
+	return apiv1alpha2.NewDrainerConfigLister(f.Informer().GetIndexer())
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2026 Giant Swarm GmbH.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha2
+
+import (
+	apiv1alpha2 "github.com/giantswarm/node-operator/api/v1alpha2"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// DrainerConfigLister helps list DrainerConfigs.
+// All objects returned here must be treated as read-only.
+type DrainerConfigLister interface {
+	// List lists all DrainerConfigs in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*apiv1alpha2.DrainerConfig, err error)
+	// DrainerConfigs returns an object that can list and get DrainerConfigs.
+	DrainerConfigs(namespace string) DrainerConfigNamespaceLister
+	DrainerConfigListerExpansion
+}
+
+// drainerConfigLister implements the DrainerConfigLister interface.
+type drainerConfigLister struct {
+	listers.ResourceIndexer[*apiv1alpha2.DrainerConfig]
+}
+
+// NewDrainerConfigLister returns a new DrainerConfigLister.
+func NewDrainerConfigLister(indexer cache.Indexer) DrainerConfigLister {
+	return &drainerConfigLister{listers.New[*apiv1alpha2.DrainerConfig](indexer, apiv1alpha2.Resource("drainerconfig"))}
+}
+
+// DrainerConfigs returns an object that can list and get DrainerConfigs.
+func (s *drainerConfigLister) DrainerConfigs(namespace string) DrainerConfigNamespaceLister {
+	return drainerConfigNamespaceLister{listers.NewNamespaced[*apiv1alpha2.DrainerConfig](s.ResourceIndexer, namespace)}
+}
+
+// DrainerConfigNamespaceLister helps list and get DrainerConfigs.
+// All objects returned here must be treated as read-only.
+type DrainerConfigNamespaceLister interface {
+	// List lists all DrainerConfigs in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*apiv1alpha2.DrainerConfig, err error)
+	// Get retrieves the DrainerConfig from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*apiv1alpha2.DrainerConfig, error)
+	DrainerConfigNamespaceListerExpansion
+}
+
+// drainerConfigNamespaceLister implements the DrainerConfigNamespaceLister
+// interface.
+type drainerConfigNamespaceLister struct {
+	listers.ResourceIndexer[*apiv1alpha2.DrainerConfig]
+}
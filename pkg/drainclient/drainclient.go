@@ -0,0 +1,256 @@
+// Package drainclient requests node drains from the node-operator and waits
+// for their outcome, so that DrainerConfig producers do not need to poll
+// DrainerConfig conditions themselves.
+//
+//	c, err := drainclient.New(drainclient.Config{Client: clientset})
+//	...
+//	d, err := c.RequestDrain(ctx, clusterID, apiEndpoint, nodeName, drainclient.Options{})
+//	...
+//	result, err := d.Wait(ctx)
+//	...
+//	err = d.Delete(ctx)
+package drainclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+	"github.com/giantswarm/node-operator/pkg/client/clientset/versioned"
+)
+
+type Config struct {
+	Client versioned.Interface
+}
+
+// Client creates DrainerConfigs and watches them until the node-operator
+// finished draining.
+type Client struct {
+	client versioned.Interface
+}
+
+func New(config Config) (*Client, error) {
+	if config.Client == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Client must not be empty", config)
+	}
+
+	c := &Client{
+		client: config.Client,
+	}
+
+	return c, nil
+}
+
+// Options configures the DrainerConfig created by RequestDrain.
+type Options struct {
+	// Action is what happens to the node. See the v1alpha2 Action constants.
+	// Defaults to draining the node.
+	Action string
+	// ClusterAPI references the Cluster API Machine of the node. The cluster,
+	// the endpoint and the node given to RequestDrain may be empty when a
+	// Machine is referenced.
+	ClusterAPI *v1alpha2.DrainerConfigSpecClusterAPI
+	// Credentials configures how the operator authenticates against the
+	// workload cluster. The endpoint given to RequestDrain may be empty for
+	// kubeconfig credentials.
+	Credentials *v1alpha2.DrainerConfigSpecWorkloadClusterCredentials
+	// Escalation configures how the drain escalates from evicting pods to
+	// deleting them when pods are left on the node for too long.
+	Escalation *v1alpha2.DrainerConfigSpecEscalation
+	// Labels are added to the DrainerConfig.
+	Labels map[string]string
+	// Name is the name of the DrainerConfig. Defaults to the node name, or
+	// the Machine name when no node is given.
+	Name string
+	// Namespace is the namespace of the DrainerConfig. Defaults to the
+	// default namespace.
+	Namespace string
+	// OnDelete is what happens to the node when the DrainerConfig is deleted.
+	// See the v1alpha2 OnDelete constants. Defaults to deleting the node.
+	OnDelete string
+	// Policy configures how the node is drained. Unset fields fall back to
+	// the node-operator defaults.
+	Policy *v1alpha2.DrainerConfigSpecPolicy
+	// Preflight configures the analysis of the pods on the node done before
+	// the node gets cordoned, e.g. to wait for pods blocking the drain.
+	Preflight *v1alpha2.DrainerConfigSpecPreflight
+	// Retry configures whether and how failed drains are retried. Wait only
+	// returns once the last attempt finished.
+	Retry *v1alpha2.DrainerConfigSpecRetry
+	// Taints are added to the node when it gets cordoned. See
+	// DrainerConfigSpec.Taints.
+	Taints []corev1.Taint
+	// UncordonOnCancel defines whether the node gets uncordoned when the
+	// drain is canceled or its DrainerConfig is deleted before the drain
+	// finished.
+	UncordonOnCancel bool
+	// VolumeDetach configures waiting for the volumes of the node to be
+	// detached before the drain is reported as done.
+	VolumeDetach *v1alpha2.DrainerConfigSpecVolumeDetach
+}
+
+// RequestDrain creates a DrainerConfig for the given workload cluster node.
+// The returned Drain is used to wait for the outcome of the drain.
+func (c *Client) RequestDrain(ctx context.Context, cluster, endpoint, node string, opts Options) (*Drain, error) {
+	name := opts.Name
+	if name == "" {
+		name = node
+	}
+	if name == "" && opts.ClusterAPI != nil {
+		name = opts.ClusterAPI.Machine
+	}
+	namespace := opts.Namespace
+	if namespace == "" {
+		namespace = metav1.NamespaceDefault
+	}
+
+	drainerConfig := &v1alpha2.DrainerConfig{
+		TypeMeta: v1alpha2.NewDrainerTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:    opts.Labels,
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: v1alpha2.DrainerConfigSpec{
+			Action:     opts.Action,
+			ClusterAPI: opts.ClusterAPI,
+			Escalation: opts.Escalation,
+			Node: v1alpha2.DrainerConfigSpecNode{
+				Name: node,
+			},
+			OnDelete:         opts.OnDelete,
+			Policy:           opts.Policy,
+			Preflight:        opts.Preflight,
+			Retry:            opts.Retry,
+			Taints:           opts.Taints,
+			UncordonOnCancel: opts.UncordonOnCancel,
+			VolumeDetach:     opts.VolumeDetach,
+			WorkloadCluster: v1alpha2.DrainerConfigSpecWorkloadCluster{
+				API: v1alpha2.DrainerConfigSpecWorkloadClusterAPI{
+					Endpoint: endpoint,
+				},
+				Credentials: opts.Credentials,
+				ID:          cluster,
+			},
+		},
+	}
+
+	_, err := c.client.CoreV1alpha2().DrainerConfigs(namespace).Create(ctx, drainerConfig, metav1.CreateOptions{})
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	d := &Drain{
+		client: c.client,
+
+		name:      name,
+		namespace: namespace,
+	}
+
+	return d, nil
+}
+
+// Drain is a drain requested using RequestDrain.
+type Drain struct {
+	client versioned.Interface
+
+	name      string
+	namespace string
+}
+
+// Name returns the name of the DrainerConfig of the drain.
+func (d *Drain) Name() string {
+	return d.name
+}
+
+// Namespace returns the namespace of the DrainerConfig of the drain.
+func (d *Drain) Namespace() string {
+	return d.namespace
+}
+
+// Cancel asks the node-operator to stop the drain. Wait returns
+// OutcomeCanceled once it did. The node gets uncordoned if the drain got
+// requested with Options.UncordonOnCancel.
+func (d *Drain) Cancel(ctx context.Context) error {
+	patch := []byte(`{"spec":{"cancel":true}}`)
+
+	_, err := d.client.CoreV1alpha2().DrainerConfigs(d.namespace).Patch(ctx, d.name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// Retry asks the node-operator to start a failed drain again. Wait returns
+// the outcome of the new drain.
+func (d *Drain) Retry(ctx context.Context) error {
+	patch := []byte(fmt.Sprintf(`{"metadata":{"annotations":{%q:"true"}}}`, v1alpha2.RetryAnnotation))
+
+	_, err := d.client.CoreV1alpha2().DrainerConfigs(d.namespace).Patch(ctx, d.name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// Delete deletes the DrainerConfig of the drain. A drain in flight is
+// abandoned.
+func (d *Drain) Delete(ctx context.Context) error {
+	err := d.client.CoreV1alpha2().DrainerConfigs(d.namespace).Delete(ctx, d.name, metav1.DeleteOptions{})
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// Wait blocks until the node-operator finished draining the node, or the
+// given context is done. The DrainerConfig is left in place, so callers
+// decide when to Delete it.
+func (d *Drain) Wait(ctx context.Context) (Result, error) {
+	fieldSelector := fields.OneTermEqualSelector("metadata.name", d.name).String()
+
+	lw := &cache.ListWatch{
+		ListWithContextFunc: func(ctx context.Context, options metav1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = fieldSelector
+			return d.client.CoreV1alpha2().DrainerConfigs(d.namespace).List(ctx, options)
+		},
+		WatchFuncWithContext: func(ctx context.Context, options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = fieldSelector
+			return d.client.CoreV1alpha2().DrainerConfigs(d.namespace).Watch(ctx, options)
+		},
+	}
+
+	var result Result
+	_, err := watchtools.UntilWithSync(ctx, lw, &v1alpha2.DrainerConfig{}, nil, func(e watch.Event) (bool, error) {
+		if e.Type == watch.Deleted {
+			return false, microerror.Maskf(drainerConfigDeletedError, "DrainerConfig %s/%s got deleted", d.namespace, d.name)
+		}
+
+		drainerConfig, ok := e.Object.(*v1alpha2.DrainerConfig)
+		if !ok {
+			return false, nil
+		}
+
+		var done bool
+		result, done = NewResult(*drainerConfig)
+		return done, nil
+	})
+	if err != nil {
+		return Result{}, microerror.Mask(err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,259 @@
+package drainclient
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1alpha1 "github.com/giantswarm/node-operator/api"
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+	"github.com/giantswarm/node-operator/pkg/client/clientset/versioned/fake"
+)
+
+func Test_Drain_Wait(t *testing.T) {
+	testCases := []struct {
+		name            string
+		condition       metav1.Condition
+		expectedOutcome Outcome
+		expectedReason  string
+	}{
+		{
+			name:            "case 0: drained node",
+			condition:       v1alpha2.DrainerConfigStatus{}.NewDrainedCondition(v1alpha2.ReasonNodeDrained, "Drained node ip-10-1-2-3"),
+			expectedOutcome: OutcomeDrained,
+			expectedReason:  v1alpha2.ReasonNodeDrained,
+		},
+		{
+			name:            "case 1: timed out drain",
+			condition:       v1alpha2.DrainerConfigStatus{}.NewTimeoutCondition(v1alpha2.ReasonTimeout, "global timeout reached"),
+			expectedOutcome: OutcomeTimedOut,
+			expectedReason:  v1alpha2.ReasonTimeout,
+		},
+		{
+			name:            "case 2: drain blocked by PodDisruptionBudgets",
+			condition:       v1alpha2.DrainerConfigStatus{}.NewTimeoutCondition(v1alpha2.ReasonPodDisruptionBudgetBlocked, "pods default/app-1 are protected by PodDisruptionBudgets"),
+			expectedOutcome: OutcomeFailed,
+			expectedReason:  v1alpha2.ReasonPodDisruptionBudgetBlocked,
+		},
+		{
+			name:            "case 3: canceled drain",
+			condition:       v1alpha2.DrainerConfigStatus{}.NewFailedCondition(v1alpha2.ReasonCanceled, "Drain of node ip-10-1-2-3 got canceled"),
+			expectedOutcome: OutcomeCanceled,
+			expectedReason:  v1alpha2.ReasonCanceled,
+		},
+		{
+			name:            "case 4: quarantined node",
+			condition:       v1alpha2.DrainerConfigStatus{}.NewQuarantinedCondition(v1alpha2.ReasonNodeQuarantined, "Node ip-10-1-2-3 got quarantined"),
+			expectedOutcome: OutcomeQuarantined,
+			expectedReason:  v1alpha2.ReasonNodeQuarantined,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			clientset := fake.NewSimpleClientset()
+
+			c, err := New(Config{Client: clientset})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			d, err := c.RequestDrain(ctx, "abc12", "api.abc12.example.com", "ip-10-1-2-3", Options{})
+			if err != nil {
+				t.Fatalf("error == %#v, want nil", err)
+			}
+
+			// Pretend to be the node-operator finishing the drain.
+			go func() {
+				drainerConfig, err := clientset.CoreV1alpha2().DrainerConfigs(d.Namespace()).Get(ctx, d.Name(), metav1.GetOptions{})
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				drainerConfig.Status.SetCondition(tc.condition)
+				_, err = clientset.CoreV1alpha2().DrainerConfigs(d.Namespace()).UpdateStatus(ctx, drainerConfig, metav1.UpdateOptions{})
+				if err != nil {
+					t.Error(err)
+				}
+			}()
+
+			result, err := d.Wait(ctx)
+			if err != nil {
+				t.Fatalf("error == %#v, want nil", err)
+			}
+
+			if result.Outcome != tc.expectedOutcome {
+				t.Fatalf("Outcome == %q, want %q", result.Outcome, tc.expectedOutcome)
+			}
+			if result.Reason != tc.expectedReason {
+				t.Fatalf("Reason == %q, want %q", result.Reason, tc.expectedReason)
+			}
+		})
+	}
+}
+
+// Test_NewResult_Conversion ensures the outcome of a drain survives a round
+// trip through v1alpha1, as done by the conversion webhook, since telling
+// canceled and timed out drains apart relies on the condition reasons.
+func Test_NewResult_Conversion(t *testing.T) {
+	testCases := []struct {
+		name            string
+		condition       metav1.Condition
+		expectedOutcome Outcome
+		expectedReason  string
+	}{
+		{
+			name:            "case 0: timed out drain",
+			condition:       v1alpha2.DrainerConfigStatus{}.NewTimeoutCondition(v1alpha2.ReasonTimeout, "global timeout reached"),
+			expectedOutcome: OutcomeTimedOut,
+			expectedReason:  v1alpha2.ReasonTimeout,
+		},
+		{
+			name:            "case 1: drain blocked by PodDisruptionBudgets",
+			condition:       v1alpha2.DrainerConfigStatus{}.NewTimeoutCondition(v1alpha2.ReasonPodDisruptionBudgetBlocked, "pods default/app-1 are protected by PodDisruptionBudgets"),
+			expectedOutcome: OutcomeFailed,
+			expectedReason:  v1alpha2.ReasonPodDisruptionBudgetBlocked,
+		},
+		{
+			name:            "case 2: canceled drain",
+			condition:       v1alpha2.DrainerConfigStatus{}.NewFailedCondition(v1alpha2.ReasonCanceled, "Drain of node ip-10-1-2-3 got canceled"),
+			expectedOutcome: OutcomeCanceled,
+			expectedReason:  v1alpha2.ReasonCanceled,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			drainerConfig := v1alpha2.DrainerConfig{
+				TypeMeta: v1alpha2.NewDrainerTypeMeta(),
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ip-10-1-2-3",
+					Namespace: "default",
+				},
+				Spec: v1alpha2.DrainerConfigSpec{
+					Node: v1alpha2.DrainerConfigSpecNode{Name: "ip-10-1-2-3"},
+					WorkloadCluster: v1alpha2.DrainerConfigSpecWorkloadCluster{
+						API: v1alpha2.DrainerConfigSpecWorkloadClusterAPI{Endpoint: "api.abc12.example.com"},
+						ID:  "abc12",
+					},
+				},
+			}
+			drainerConfig.Status.SetCondition(tc.condition)
+
+			var old v1alpha1.DrainerConfig
+			err := old.ConvertFrom(&drainerConfig)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// The v1alpha1 object is sent over the wire like any other.
+			b, err := json.Marshal(old)
+			if err != nil {
+				t.Fatal(err)
+			}
+			old = v1alpha1.DrainerConfig{}
+			err = json.Unmarshal(b, &old)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var converted v1alpha2.DrainerConfig
+			err = old.ConvertTo(&converted)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result, done := NewResult(converted)
+			if !done {
+				t.Fatalf("NewResult() is not done, want done")
+			}
+			if result.Outcome != tc.expectedOutcome {
+				t.Fatalf("Outcome == %q, want %q", result.Outcome, tc.expectedOutcome)
+			}
+			if result.Reason != tc.expectedReason {
+				t.Fatalf("Reason == %q, want %q", result.Reason, tc.expectedReason)
+			}
+		})
+	}
+}
+
+func Test_NewResult_Pending(t *testing.T) {
+	drainerConfig := v1alpha2.DrainerConfig{}
+	drainerConfig.Status.SetCondition(drainerConfig.Status.NewDrainingCondition(v1alpha2.ReasonDrainStarted, "Evicting pods"))
+
+	_, done := NewResult(drainerConfig)
+	if done {
+		t.Fatalf("NewResult() == done for a drain in flight")
+	}
+}
+
+func Test_Drain_Cancel(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewSimpleClientset()
+
+	c, err := New(Config{Client: clientset})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := c.RequestDrain(ctx, "abc12", "api.abc12.example.com", "ip-10-1-2-3", Options{UncordonOnCancel: true})
+	if err != nil {
+		t.Fatalf("error == %#v, want nil", err)
+	}
+
+	err = d.Cancel(ctx)
+	if err != nil {
+		t.Fatalf("error == %#v, want nil", err)
+	}
+
+	drainerConfig, err := clientset.CoreV1alpha2().DrainerConfigs(d.Namespace()).Get(ctx, d.Name(), metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !drainerConfig.Spec.Cancel {
+		t.Fatalf("Spec.Cancel == false, want true")
+	}
+	if !drainerConfig.Spec.UncordonOnCancel {
+		t.Fatalf("Spec.UncordonOnCancel == false, want true")
+	}
+}
+
+func Test_Drain_Retry(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewSimpleClientset()
+
+	c, err := New(Config{Client: clientset})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := c.RequestDrain(ctx, "abc12", "api.abc12.example.com", "ip-10-1-2-3", Options{})
+	if err != nil {
+		t.Fatalf("error == %#v, want nil", err)
+	}
+
+	err = d.Retry(ctx)
+	if err != nil {
+		t.Fatalf("error == %#v, want nil", err)
+	}
+
+	drainerConfig, err := clientset.CoreV1alpha2().DrainerConfigs(d.Namespace()).Get(ctx, d.Name(), metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := drainerConfig.Annotations[v1alpha2.RetryAnnotation]; !ok {
+		t.Fatalf("annotation %s is missing", v1alpha2.RetryAnnotation)
+	}
+
+	// The outcome of the failed drain must not be reported again
+	drainerConfig.Status.SetCondition(drainerConfig.Status.NewTimeoutCondition(v1alpha2.ReasonTimeout, "Failed to drain node ip-10-1-2-3"))
+	if _, done := NewResult(*drainerConfig); done {
+		t.Fatalf("NewResult() is done, want not done while the retry is pending")
+	}
+}
@@ -0,0 +1,22 @@
+package drainclient
+
+import "github.com/giantswarm/microerror"
+
+var invalidConfigError = &microerror.Error{
+	Kind: "invalidConfigError",
+}
+
+// IsInvalidConfig asserts invalidConfigError.
+func IsInvalidConfig(err error) bool {
+	return microerror.Cause(err) == invalidConfigError
+}
+
+var drainerConfigDeletedError = &microerror.Error{
+	Kind: "drainerConfigDeletedError",
+}
+
+// IsDrainerConfigDeleted asserts drainerConfigDeletedError, which is returned
+// by Wait when the DrainerConfig got deleted before the drain finished.
+func IsDrainerConfigDeleted(err error) bool {
+	return microerror.Cause(err) == drainerConfigDeletedError
+}
@@ -0,0 +1,92 @@
+package drainclient
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+)
+
+// Outcome is the outcome of a drain.
+type Outcome string
+
+const (
+	// OutcomeCanceled means the drain got canceled, e.g. using Cancel.
+	OutcomeCanceled Outcome = "Canceled"
+	// OutcomeDrained means all pods got evicted or deleted from the node, or
+	// the node does not exist anymore.
+	OutcomeDrained Outcome = "Drained"
+	// OutcomeFailed means the node-operator gave up draining the node for
+	// another reason than its timeout, e.g. pods protected by
+	// PodDisruptionBudgets. The reason and message tell why.
+	OutcomeFailed Outcome = "Failed"
+	// OutcomeQuarantined means the node got cordoned, and tainted, without
+	// being drained, as requested with the Cordon and Taint actions.
+	OutcomeQuarantined Outcome = "Quarantined"
+	// OutcomeTimedOut means the drain did not finish within its timeout.
+	OutcomeTimedOut Outcome = "TimedOut"
+)
+
+// Result is the outcome of a drain, as reported by the conditions of its
+// DrainerConfig.
+type Result struct {
+	// DrainerConfig is the DrainerConfig the result was taken from.
+	DrainerConfig v1alpha2.DrainerConfig
+	// Message is the human readable message of the condition telling the
+	// outcome, e.g. the pods which could not be evicted.
+	Message string
+	// Outcome is the outcome of the drain.
+	Outcome Outcome
+	// Reason is the reason of the condition telling the outcome, e.g.
+	// PodDisruptionBudgetBlocked. See the v1alpha2 Reason constants.
+	Reason string
+	// UnevictedPods are the pods left on the node by a failed drain, together
+	// with the reason each of them was presumably not evicted.
+	UnevictedPods []v1alpha2.DrainerConfigStatusPod
+}
+
+// NewResult returns the result of the drain of the given DrainerConfig. It
+// returns false as long as the drain is not finished yet, including failed
+// drains which get retried.
+func NewResult(drainerConfig v1alpha2.DrainerConfig) (Result, bool) {
+	// The outcome of the previous drain is stale as long as the retry is
+	// not picked up
+	if _, ok := drainerConfig.Annotations[v1alpha2.RetryAnnotation]; ok {
+		return Result{}, false
+	}
+
+	status := drainerConfig.Status
+
+	var outcome Outcome
+	var conditionType string
+	switch {
+	case status.HasDrainedCondition():
+		outcome = OutcomeDrained
+		conditionType = v1alpha2.ConditionTypeDrained
+	case status.HasQuarantinedCondition():
+		outcome = OutcomeQuarantined
+		conditionType = v1alpha2.ConditionTypeQuarantined
+	case status.HasTimeoutCondition():
+		outcome = OutcomeFailed
+		conditionType = v1alpha2.ConditionTypeTimeout
+	case status.HasFailedCondition() && meta.FindStatusCondition(status.Conditions, v1alpha2.ConditionTypeFailed).Reason == v1alpha2.ReasonCanceled:
+		outcome = OutcomeCanceled
+		conditionType = v1alpha2.ConditionTypeFailed
+	default:
+		return Result{}, false
+	}
+
+	c := meta.FindStatusCondition(status.Conditions, conditionType)
+	if outcome == OutcomeFailed && c.Reason == v1alpha2.ReasonTimeout {
+		outcome = OutcomeTimedOut
+	}
+
+	result := Result{
+		DrainerConfig: drainerConfig,
+		Message:       c.Message,
+		Outcome:       outcome,
+		Reason:        c.Reason,
+		UnevictedPods: status.UnevictedPods,
+	}
+
+	return result, true
+}
@@ -0,0 +1,125 @@
+package conversion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	kitendpoint "github.com/go-kit/kit/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/node-operator/service/conversion"
+)
+
+const (
+	// Method is the HTTP method this endpoint is registered for.
+	Method = "POST"
+	// Name identifies the endpoint. It is aligned to the package path.
+	Name = "conversion"
+	// Path is the HTTP request path this endpoint is registered for.
+	Path = conversion.Path
+)
+
+// Config represents the configuration used to create a conversion endpoint.
+type Config struct {
+	// Dependencies.
+	Logger  micrologger.Logger
+	Service *conversion.Service
+}
+
+// New creates a new configured conversion endpoint, which serves the
+// ConversionReviews sent by the Kubernetes API server for DrainerConfigs.
+func New(config Config) (*Endpoint, error) {
+	// Dependencies.
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "logger must not be empty")
+	}
+	if config.Service == nil {
+		return nil, microerror.Maskf(invalidConfigError, "service must not be empty")
+	}
+
+	newEndpoint := &Endpoint{
+		Config: config,
+	}
+
+	return newEndpoint, nil
+}
+
+type Endpoint struct {
+	Config
+}
+
+func (e *Endpoint) Decoder() kithttp.DecodeRequestFunc {
+	return func(ctx context.Context, r *http.Request) (interface{}, error) {
+		var review apiextensionsv1.ConversionReview
+		err := json.NewDecoder(r.Body).Decode(&review)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		if review.Request == nil {
+			return nil, microerror.Maskf(invalidRequestError, "conversion review request must not be empty")
+		}
+
+		return &review, nil
+	}
+}
+
+func (e *Endpoint) Encoder() kithttp.EncodeResponseFunc {
+	return func(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+		return json.NewEncoder(w).Encode(response)
+	}
+}
+
+func (e *Endpoint) Endpoint() kitendpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		review := request.(*apiextensionsv1.ConversionReview)
+
+		response := &apiextensionsv1.ConversionResponse{
+			UID: review.Request.UID,
+		}
+
+		objects, err := e.Service.Convert(ctx, review.Request.Objects, review.Request.DesiredAPIVersion)
+		if err != nil {
+			// Conversion failures are reported to the Kubernetes API server as
+			// part of the review, not as an HTTP error.
+			e.Logger.LogCtx(ctx, "level", "error", "message", "failed to convert DrainerConfigs", "stack", microerror.JSON(err))
+
+			response.Result = metav1.Status{
+				Message: err.Error(),
+				Status:  metav1.StatusFailure,
+			}
+		} else {
+			response.ConvertedObjects = objects
+			response.Result = metav1.Status{
+				Status: metav1.StatusSuccess,
+			}
+		}
+
+		review.Request = nil
+		review.Response = response
+
+		return review, nil
+	}
+}
+
+func (e *Endpoint) Method() string {
+	return Method
+}
+
+func (e *Endpoint) Middlewares() []kitendpoint.Middleware {
+	return []kitendpoint.Middleware{}
+}
+
+func (e *Endpoint) Name() string {
+	return Name
+}
+
+func (e *Endpoint) Path() string {
+	return Path
+}
@@ -6,6 +6,9 @@ import (
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 
+	"github.com/giantswarm/node-operator/server/endpoint/conversion"
+	"github.com/giantswarm/node-operator/server/endpoint/mutation"
+	"github.com/giantswarm/node-operator/server/endpoint/validation"
 	"github.com/giantswarm/node-operator/service"
 )
 
@@ -16,13 +19,29 @@ type Config struct {
 
 // Endpoint is the endpoint collection.
 type Endpoint struct {
-	Healthz *healthz.Endpoint
-	Version *versionendpoint.Endpoint
+	Conversion *conversion.Endpoint
+	Healthz    *healthz.Endpoint
+	Mutation   *mutation.Endpoint
+	Validation *validation.Endpoint
+	Version    *versionendpoint.Endpoint
 }
 
 func New(config Config) (*Endpoint, error) {
 	var err error
 
+	var conversionEndpoint *conversion.Endpoint
+	{
+		c := conversion.Config{
+			Logger:  config.Logger,
+			Service: config.Service.Conversion,
+		}
+
+		conversionEndpoint, err = conversion.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	var healthzEndpoint *healthz.Endpoint
 	{
 		c := healthz.Config{
@@ -35,6 +54,32 @@ func New(config Config) (*Endpoint, error) {
 		}
 	}
 
+	var mutationEndpoint *mutation.Endpoint
+	{
+		c := mutation.Config{
+			Logger:  config.Logger,
+			Service: config.Service.Admission,
+		}
+
+		mutationEndpoint, err = mutation.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var validationEndpoint *validation.Endpoint
+	{
+		c := validation.Config{
+			Logger:  config.Logger,
+			Service: config.Service.Admission,
+		}
+
+		validationEndpoint, err = validation.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	var versionEndpoint *versionendpoint.Endpoint
 	{
 		c := versionendpoint.Config{
@@ -49,8 +94,11 @@ func New(config Config) (*Endpoint, error) {
 	}
 
 	e := &Endpoint{
-		Healthz: healthzEndpoint,
-		Version: versionEndpoint,
+		Conversion: conversionEndpoint,
+		Healthz:    healthzEndpoint,
+		Mutation:   mutationEndpoint,
+		Validation: validationEndpoint,
+		Version:    versionEndpoint,
 	}
 
 	return e, nil
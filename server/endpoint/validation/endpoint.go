@@ -0,0 +1,120 @@
+package validation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	kitendpoint "github.com/go-kit/kit/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/node-operator/service/admission"
+)
+
+const (
+	// Method is the HTTP method this endpoint is registered for.
+	Method = "POST"
+	// Name identifies the endpoint. It is aligned to the package path.
+	Name = "validation"
+	// Path is the HTTP request path this endpoint is registered for.
+	Path = admission.ValidationPath
+)
+
+// Config represents the configuration used to create a validation endpoint.
+type Config struct {
+	// Dependencies.
+	Logger  micrologger.Logger
+	Service *admission.Service
+}
+
+// New creates a new configured validation endpoint, which serves the
+// AdmissionReviews sent by the Kubernetes API server for DrainerConfigs.
+func New(config Config) (*Endpoint, error) {
+	// Dependencies.
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "logger must not be empty")
+	}
+	if config.Service == nil {
+		return nil, microerror.Maskf(invalidConfigError, "service must not be empty")
+	}
+
+	newEndpoint := &Endpoint{
+		Config: config,
+	}
+
+	return newEndpoint, nil
+}
+
+type Endpoint struct {
+	Config
+}
+
+func (e *Endpoint) Decoder() kithttp.DecodeRequestFunc {
+	return func(ctx context.Context, r *http.Request) (interface{}, error) {
+		var review admissionv1.AdmissionReview
+		err := json.NewDecoder(r.Body).Decode(&review)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		if review.Request == nil {
+			return nil, microerror.Maskf(invalidRequestError, "admission review request must not be empty")
+		}
+
+		return &review, nil
+	}
+}
+
+func (e *Endpoint) Encoder() kithttp.EncodeResponseFunc {
+	return func(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+		return json.NewEncoder(w).Encode(response)
+	}
+}
+
+func (e *Endpoint) Endpoint() kitendpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		review := request.(*admissionv1.AdmissionReview)
+
+		response, err := e.Service.Validate(ctx, review.Request)
+		if err != nil {
+			// Failures are reported to the Kubernetes API server as part of the
+			// review, not as an HTTP error.
+			e.Logger.LogCtx(ctx, "level", "error", "message", "failed to validate DrainerConfig", "stack", microerror.JSON(err))
+
+			response = &admissionv1.AdmissionResponse{
+				Result: &metav1.Status{
+					Code:    http.StatusInternalServerError,
+					Message: err.Error(),
+					Status:  metav1.StatusFailure,
+				},
+				UID: review.Request.UID,
+			}
+		}
+
+		review.Request = nil
+		review.Response = response
+
+		return review, nil
+	}
+}
+
+func (e *Endpoint) Method() string {
+	return Method
+}
+
+func (e *Endpoint) Middlewares() []kitendpoint.Middleware {
+	return []kitendpoint.Middleware{}
+}
+
+func (e *Endpoint) Name() string {
+	return Name
+}
+
+func (e *Endpoint) Path() string {
+	return Path
+}
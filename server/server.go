@@ -12,12 +12,14 @@ import (
 	"github.com/giantswarm/micrologger"
 	"github.com/spf13/viper"
 
+	"github.com/giantswarm/node-operator/flag"
 	"github.com/giantswarm/node-operator/server/endpoint"
 	"github.com/giantswarm/node-operator/service"
 )
 
 // Config represents the configuration used to create a new server object.
 type Config struct {
+	Flag    *flag.Flag
 	Logger  micrologger.Logger
 	Service *service.Service
 	Viper   *viper.Viper
@@ -29,6 +31,20 @@ type Config struct {
 func New(config Config) (microserver.Server, error) {
 	var err error
 
+	if config.Flag == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Flag must not be empty", config)
+	}
+	if config.Viper == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Viper must not be empty", config)
+	}
+
+	webhookAddress := config.Viper.GetString(config.Flag.Service.Webhook.Listen.Address)
+	webhookCrtFile := config.Viper.GetString(config.Flag.Service.Webhook.TLS.CrtFile)
+	webhookKeyFile := config.Viper.GetString(config.Flag.Service.Webhook.TLS.KeyFile)
+	if webhookAddress != "" && (webhookCrtFile == "" || webhookKeyFile == "") {
+		return nil, microerror.Maskf(invalidConfigError, "webhook TLS certificate and key must not be empty when the webhook listen address is set")
+	}
+
 	var endpointCollection *endpoint.Endpoint
 	{
 		c := endpoint.Config{
@@ -60,6 +76,22 @@ func New(config Config) (microserver.Server, error) {
 		shutdownOnce: sync.Once{},
 	}
 
+	// The Kubernetes API server only calls webhooks using HTTPS, so the
+	// microkit server listens at the webhook address using HTTPS when the
+	// webhooks are served. The daemon only falls back to its own listen and
+	// TLS flags for the fields we leave empty. The certificate is read when
+	// the server boots.
+	if webhookAddress != "" {
+		newServer.config.Endpoints = append(newServer.config.Endpoints,
+			endpointCollection.Conversion,
+			endpointCollection.Mutation,
+			endpointCollection.Validation,
+		)
+		newServer.config.ListenAddress = "https://" + webhookAddress
+		newServer.config.TLSCrtFile = webhookCrtFile
+		newServer.config.TLSKeyFile = webhookKeyFile
+	}
+
 	return newServer, nil
 }
 
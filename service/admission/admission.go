@@ -0,0 +1,472 @@
+// Package admission validates and defaults DrainerConfigs on behalf of the
+// admission webhooks called by the Kubernetes API server.
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/giantswarm/k8sclient/v7/pkg/k8sclient"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"gomodules.xyz/jsonpatch/v2"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+	"github.com/giantswarm/node-operator/service/controller/key"
+	"github.com/giantswarm/node-operator/service/controller/resource/drainer"
+)
+
+const (
+	// MutationPath is the URL path the mutating webhook is served at.
+	MutationPath = "/mutate"
+	// ValidationPath is the URL path the validating webhook is served at.
+	ValidationPath = "/validate"
+)
+
+type Config struct {
+	K8sClient k8sclient.Interface
+	Logger    micrologger.Logger
+
+	ControlPlaneDrainPolicy drainer.DrainPolicy
+	WorkerDrainPolicy       drainer.DrainPolicy
+}
+
+type Service struct {
+	k8sClient k8sclient.Interface
+	logger    micrologger.Logger
+
+	controlPlaneDrainPolicy drainer.DrainPolicy
+	workerDrainPolicy       drainer.DrainPolicy
+}
+
+func New(config Config) (*Service, error) {
+	if config.K8sClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.K8sClient must not be empty", config)
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+
+	s := &Service{
+		k8sClient: config.K8sClient,
+		logger:    config.Logger,
+
+		controlPlaneDrainPolicy: config.ControlPlaneDrainPolicy,
+		workerDrainPolicy:       config.WorkerDrainPolicy,
+	}
+
+	return s, nil
+}
+
+// Mutate fills the unset fields of the drain policy of the DrainerConfig under
+// review. Only defaults which are the same for control plane and worker nodes
+// are filled, since the kind of node is only known once the node is drained.
+func (s *Service) Mutate(ctx context.Context, request *admissionv1.AdmissionRequest) (*admissionv1.AdmissionResponse, error) {
+	drainerConfig, err := decodeDrainerConfig(request.Object.Raw)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	mutated := drainerConfig.DeepCopy()
+	mutated.Spec.Policy = defaultPolicy(s.controlPlaneDrainPolicy, s.workerDrainPolicy, mutated.Spec.Policy)
+
+	response := &admissionv1.AdmissionResponse{
+		Allowed: true,
+		UID:     request.UID,
+	}
+
+	if apiequality.Semantic.DeepEqual(drainerConfig.Spec.Policy, mutated.Spec.Policy) {
+		return response, nil
+	}
+
+	b, err := json.Marshal(mutated)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	operations, err := jsonpatch.CreatePatch(request.Object.Raw, b)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	patch, err := json.Marshal(operations)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	patchType := admissionv1.PatchTypeJSONPatch
+	response.Patch = patch
+	response.PatchType = &patchType
+
+	return response, nil
+}
+
+// Validate rejects DrainerConfigs with malformed specs, changes to immutable
+// fields and DrainerConfigs draining a node which is drained by another
+// DrainerConfig already.
+func (s *Service) Validate(ctx context.Context, request *admissionv1.AdmissionRequest) (*admissionv1.AdmissionResponse, error) {
+	drainerConfig, err := decodeDrainerConfig(request.Object.Raw)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var allErrs field.ErrorList
+	switch request.Operation {
+	case admissionv1.Create:
+		allErrs = validateSpec(drainerConfig.Spec)
+
+		errs, err := s.validateUnique(ctx, drainerConfig)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		allErrs = append(allErrs, errs...)
+	case admissionv1.Update:
+		old, err := decodeDrainerConfig(request.OldObject.Raw)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		// Updates of DrainerConfigs being deleted, like removing finalizers,
+		// and updates leaving the spec alone, like status or label changes,
+		// must not be rejected because of specs which were valid under older
+		// rules.
+		if drainerConfig.DeletionTimestamp != nil || apiequality.Semantic.DeepEqual(old.Spec, drainerConfig.Spec) {
+			break
+		}
+
+		allErrs = validateSpec(drainerConfig.Spec)
+		allErrs = append(allErrs, validateImmutable(old.Spec, drainerConfig.Spec)...)
+	default:
+		allErrs = validateSpec(drainerConfig.Spec)
+	}
+
+	response := &admissionv1.AdmissionResponse{
+		Allowed: len(allErrs) == 0,
+		UID:     request.UID,
+	}
+	if len(allErrs) > 0 {
+		response.Result = &metav1.Status{
+			Code:    422,
+			Message: allErrs.ToAggregate().Error(),
+			Reason:  metav1.StatusReasonInvalid,
+			Status:  metav1.StatusFailure,
+		}
+	}
+
+	return response, nil
+}
+
+func (s *Service) validateUnique(ctx context.Context, drainerConfig v1alpha2.DrainerConfig) (field.ErrorList, error) {
+	var list v1alpha2.DrainerConfigList
+	err := s.k8sClient.CtrlClient().List(ctx, &list)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	for _, d := range list.Items {
+		if d.Namespace == drainerConfig.Namespace && d.Name == drainerConfig.Name {
+			continue
+		}
+		// DrainerConfigs being deleted or done draining do not block new ones
+		// for the same node, e.g. when a node needs to be drained again.
+		if d.DeletionTimestamp != nil || d.Status.HasDrainedCondition() || d.Status.HasTimeoutCondition() {
+			continue
+		}
+		if key.MachineFromDrainerConfig(drainerConfig) != "" {
+			if d.Namespace != drainerConfig.Namespace || key.MachineFromDrainerConfig(d) != key.MachineFromDrainerConfig(drainerConfig) {
+				continue
+			}
+
+			return field.ErrorList{
+				field.Duplicate(field.NewPath("spec", "clusterAPI", "machine"), fmt.Sprintf("%s is drained by DrainerConfig %s/%s already", key.MachineFromDrainerConfig(d), d.Namespace, d.Name)),
+			}, nil
+		}
+		// The node of Machines is only known once it joined the cluster
+		if key.MachineFromDrainerConfig(d) != "" {
+			continue
+		}
+		if key.ClusterIDFromDrainerConfig(d) != key.ClusterIDFromDrainerConfig(drainerConfig) {
+			continue
+		}
+		if key.NodeNameFromDrainerConfig(d) != key.NodeNameFromDrainerConfig(drainerConfig) {
+			continue
+		}
+
+		return field.ErrorList{
+			field.Duplicate(field.NewPath("spec", "node", "name"), fmt.Sprintf("%s is drained by DrainerConfig %s/%s already", key.NodeNameFromDrainerConfig(d), d.Namespace, d.Name)),
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// defaultPolicy fills the unset fields of the given policy with the defaults
+// which are the same for control plane and worker nodes.
+func defaultPolicy(controlPlane, worker drainer.DrainPolicy, p *v1alpha2.DrainerConfigSpecPolicy) *v1alpha2.DrainerConfigSpecPolicy {
+	policy := &v1alpha2.DrainerConfigSpecPolicy{}
+	if p != nil {
+		policy = p.DeepCopy()
+	}
+
+	if policy.DeleteEmptyDirData == nil && controlPlane.DeleteEmptyDirData == worker.DeleteEmptyDirData {
+		policy.DeleteEmptyDirData = &worker.DeleteEmptyDirData
+	}
+	if policy.DisableEviction == nil && controlPlane.DisableEviction == worker.DisableEviction {
+		policy.DisableEviction = &worker.DisableEviction
+	}
+	if policy.Force == nil && controlPlane.Force == worker.Force {
+		policy.Force = &worker.Force
+	}
+	if policy.GracePeriodSeconds == nil && controlPlane.GracePeriodSeconds == worker.GracePeriodSeconds {
+		policy.GracePeriodSeconds = &worker.GracePeriodSeconds
+	}
+	if policy.SkipWaitForDeleteTimeoutSeconds == nil && controlPlane.SkipWaitForDeleteTimeoutSeconds == worker.SkipWaitForDeleteTimeoutSeconds {
+		policy.SkipWaitForDeleteTimeoutSeconds = &worker.SkipWaitForDeleteTimeoutSeconds
+	}
+	if policy.Timeout == nil && controlPlane.Timeout == worker.Timeout {
+		policy.Timeout = &metav1.Duration{Duration: worker.Timeout}
+	}
+
+	if apiequality.Semantic.DeepEqual(policy, &v1alpha2.DrainerConfigSpecPolicy{}) {
+		return p
+	}
+
+	return policy
+}
+
+func validateImmutable(old, new v1alpha2.DrainerConfigSpec) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if new.WorkloadCluster.ID != old.WorkloadCluster.ID {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "workloadCluster", "id"), "field is immutable"))
+	}
+	if new.WorkloadCluster.API.Endpoint != old.WorkloadCluster.API.Endpoint {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "workloadCluster", "api", "endpoint"), "field is immutable"))
+	}
+	if new.Node.Name != old.Node.Name {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "node", "name"), "field is immutable"))
+	}
+	if !apiequality.Semantic.DeepEqual(new.ClusterAPI, old.ClusterAPI) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "clusterAPI"), "field is immutable"))
+	}
+	if new.Action != old.Action {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "action"), "field is immutable"))
+	}
+	if !apiequality.Semantic.DeepEqual(new.Taints, old.Taints) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "taints"), "field is immutable"))
+	}
+
+	return allErrs
+}
+
+func validateSpec(spec v1alpha2.DrainerConfigSpec) field.ErrorList {
+	var allErrs field.ErrorList
+
+	// Drainer configs of Cluster API clusters reach the workload cluster via
+	// its kubeconfig Secret and may reference a Machine instead of the
+	// cluster and the node
+	var machine string
+	if spec.ClusterAPI != nil {
+		machine = spec.ClusterAPI.Machine
+		if machine != "" {
+			p := field.NewPath("spec", "clusterAPI", "machine")
+			for _, msg := range validation.IsDNS1123Subdomain(machine) {
+				allErrs = append(allErrs, field.Invalid(p, machine, msg))
+			}
+		}
+	}
+
+	{
+		p := field.NewPath("spec", "workloadCluster", "id")
+		if spec.WorkloadCluster.ID == "" {
+			if machine == "" {
+				allErrs = append(allErrs, field.Required(p, ""))
+			}
+		} else {
+			for _, msg := range validation.IsDNS1123Label(spec.WorkloadCluster.ID) {
+				allErrs = append(allErrs, field.Invalid(p, spec.WorkloadCluster.ID, msg))
+			}
+		}
+	}
+
+	{
+		p := field.NewPath("spec", "workloadCluster", "api", "endpoint")
+		if spec.WorkloadCluster.API.Endpoint == "" {
+			// Kubeconfigs tell the endpoint themselves
+			if key.CredentialsTypeFromDrainerConfig(v1alpha2.DrainerConfig{Spec: spec}) != v1alpha2.CredentialsTypeKubeconfig {
+				allErrs = append(allErrs, field.Required(p, ""))
+			}
+		} else if msg := validateEndpoint(spec.WorkloadCluster.API.Endpoint); msg != "" {
+			allErrs = append(allErrs, field.Invalid(p, spec.WorkloadCluster.API.Endpoint, msg))
+		}
+	}
+
+	if c := spec.WorkloadCluster.Credentials; c != nil {
+		p := field.NewPath("spec", "workloadCluster", "credentials")
+		switch c.Type {
+		case "", v1alpha2.CredentialsTypeCerts, v1alpha2.CredentialsTypeKubeconfig, v1alpha2.CredentialsTypeServiceAccountToken:
+		default:
+			allErrs = append(allErrs, field.NotSupported(p.Child("type"), c.Type, []string{
+				v1alpha2.CredentialsTypeCerts,
+				v1alpha2.CredentialsTypeKubeconfig,
+				v1alpha2.CredentialsTypeServiceAccountToken,
+			}))
+		}
+		if c.Secret == nil || c.Secret.Name == "" {
+			if c.Type == v1alpha2.CredentialsTypeServiceAccountToken {
+				allErrs = append(allErrs, field.Required(p.Child("secret", "name"), ""))
+			}
+		} else {
+			for _, msg := range validation.IsDNS1123Subdomain(c.Secret.Name) {
+				allErrs = append(allErrs, field.Invalid(p.Child("secret", "name"), c.Secret.Name, msg))
+			}
+		}
+		if c.Secret != nil && c.Secret.Key != "" {
+			for _, msg := range validation.IsConfigMapKey(c.Secret.Key) {
+				allErrs = append(allErrs, field.Invalid(p.Child("secret", "key"), c.Secret.Key, msg))
+			}
+		}
+	}
+
+	{
+		p := field.NewPath("spec", "node", "name")
+		if spec.Node.Name == "" {
+			if machine == "" {
+				allErrs = append(allErrs, field.Required(p, ""))
+			}
+		} else {
+			for _, msg := range validation.IsDNS1123Subdomain(spec.Node.Name) {
+				allErrs = append(allErrs, field.Invalid(p, spec.Node.Name, msg))
+			}
+		}
+	}
+
+	switch spec.Action {
+	case "", v1alpha2.ActionCordon, v1alpha2.ActionDrain, v1alpha2.ActionDrainAndDelete, v1alpha2.ActionTaint:
+	default:
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("spec", "action"), spec.Action, []string{
+			v1alpha2.ActionCordon,
+			v1alpha2.ActionDrain,
+			v1alpha2.ActionDrainAndDelete,
+			v1alpha2.ActionTaint,
+		}))
+	}
+
+	for i, t := range spec.Taints {
+		p := field.NewPath("spec", "taints").Index(i)
+		if t.Key == "" {
+			allErrs = append(allErrs, field.Required(p.Child("key"), ""))
+		} else {
+			for _, msg := range validation.IsQualifiedName(t.Key) {
+				allErrs = append(allErrs, field.Invalid(p.Child("key"), t.Key, msg))
+			}
+		}
+		if t.Value != "" {
+			for _, msg := range validation.IsValidLabelValue(t.Value) {
+				allErrs = append(allErrs, field.Invalid(p.Child("value"), t.Value, msg))
+			}
+		}
+		switch t.Effect {
+		case corev1.TaintEffectNoExecute, corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule:
+		default:
+			allErrs = append(allErrs, field.NotSupported(p.Child("effect"), t.Effect, []string{
+				string(corev1.TaintEffectNoExecute),
+				string(corev1.TaintEffectNoSchedule),
+				string(corev1.TaintEffectPreferNoSchedule),
+			}))
+		}
+	}
+
+	switch spec.OnDelete {
+	case "", v1alpha2.OnDeleteDeleteNode, v1alpha2.OnDeleteLeaveCordoned, v1alpha2.OnDeleteUncordonNode:
+	default:
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("spec", "onDelete"), spec.OnDelete, []string{
+			v1alpha2.OnDeleteDeleteNode,
+			v1alpha2.OnDeleteLeaveCordoned,
+			v1alpha2.OnDeleteUncordonNode,
+		}))
+	}
+
+	if spec.Escalation != nil {
+		p := field.NewPath("spec", "escalation")
+		if spec.Escalation.DeleteAfter != nil && spec.Escalation.DeleteAfter.Duration < 0 {
+			allErrs = append(allErrs, field.Invalid(p.Child("deleteAfter"), spec.Escalation.DeleteAfter.Duration.String(), "must not be negative"))
+		}
+		if spec.Escalation.ForceDeleteAfter != nil && spec.Escalation.ForceDeleteAfter.Duration < 0 {
+			allErrs = append(allErrs, field.Invalid(p.Child("forceDeleteAfter"), spec.Escalation.ForceDeleteAfter.Duration.String(), "must not be negative"))
+		}
+	}
+
+	if spec.Retry != nil {
+		p := field.NewPath("spec", "retry")
+		if spec.Retry.Backoff != nil && spec.Retry.Backoff.Duration < 0 {
+			allErrs = append(allErrs, field.Invalid(p.Child("backoff"), spec.Retry.Backoff.Duration.String(), "must not be negative"))
+		}
+		if spec.Retry.EscalateToDeleteAfter < 0 {
+			allErrs = append(allErrs, field.Invalid(p.Child("escalateToDeleteAfter"), spec.Retry.EscalateToDeleteAfter, "must not be negative"))
+		}
+		if spec.Retry.MaxAttempts < 0 {
+			allErrs = append(allErrs, field.Invalid(p.Child("maxAttempts"), spec.Retry.MaxAttempts, "must not be negative"))
+		}
+	}
+
+	if spec.VolumeDetach != nil && spec.VolumeDetach.Timeout != nil && spec.VolumeDetach.Timeout.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "volumeDetach", "timeout"), spec.VolumeDetach.Timeout.Duration.String(), "must not be negative"))
+	}
+
+	err := drainer.ValidateDrainPolicy(spec.Policy)
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "policy"), spec.Policy, err.Error()))
+	}
+
+	return allErrs
+}
+
+// validateEndpoint checks the workload cluster API endpoint, which is either a
+// host name, optionally with a port, or a URL.
+func validateEndpoint(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		u, err = url.Parse("https://" + endpoint)
+		if err != nil {
+			return err.Error()
+		}
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "must use the http or https scheme"
+	}
+	if u.Path != "" && u.Path != "/" {
+		return "must not contain a path"
+	}
+
+	host := u.Hostname()
+	if host == "" {
+		return "must contain a host name"
+	}
+	if msgs := validation.IsDNS1123Subdomain(host); len(msgs) > 0 && len(validation.IsValidIP(field.NewPath(""), host)) > 0 {
+		return fmt.Sprintf("must contain a valid host name: %s", msgs[0])
+	}
+
+	return ""
+}
+
+func decodeDrainerConfig(raw []byte) (v1alpha2.DrainerConfig, error) {
+	var drainerConfig v1alpha2.DrainerConfig
+	err := json.Unmarshal(raw, &drainerConfig)
+	if err != nil {
+		return v1alpha2.DrainerConfig{}, microerror.Mask(err)
+	}
+
+	if drainerConfig.APIVersion != v1alpha2.SchemeGroupVersion.String() {
+		return v1alpha2.DrainerConfig{}, microerror.Maskf(unsupportedVersionError, "API version %q is not supported", drainerConfig.APIVersion)
+	}
+
+	return drainerConfig, nil
+}
@@ -0,0 +1,486 @@
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/giantswarm/k8sclient/v7/pkg/k8sclienttest"
+	"github.com/giantswarm/micrologger/microloggertest"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+	"github.com/giantswarm/node-operator/service/controller/resource/drainer"
+)
+
+var (
+	testControlPlaneDrainPolicy = drainer.DrainPolicy{
+		DeleteEmptyDirData:              true,
+		Force:                           true,
+		GracePeriodSeconds:              45,
+		SkipWaitForDeleteTimeoutSeconds: 15,
+		Timeout:                         2 * time.Minute,
+	}
+	testWorkerDrainPolicy = drainer.DrainPolicy{
+		DeleteEmptyDirData:              true,
+		Force:                           true,
+		GracePeriodSeconds:              60,
+		SkipWaitForDeleteTimeoutSeconds: 15,
+		Timeout:                         5 * time.Minute,
+	}
+)
+
+func Test_Validate(t *testing.T) {
+	testCases := []struct {
+		name            string
+		operation       admissionv1.Operation
+		drainerConfig   v1alpha2.DrainerConfig
+		old             *v1alpha2.DrainerConfig
+		existing        []v1alpha2.DrainerConfig
+		expectedAllowed bool
+	}{
+		{
+			name:            "case 0: valid DrainerConfig is allowed",
+			operation:       admissionv1.Create,
+			drainerConfig:   newTestDrainerConfig("node-1", "abc12", "api.abc12.example.com", "ip-10-1-2-3"),
+			expectedAllowed: true,
+		},
+		{
+			name:            "case 1: endpoint given as URL is allowed",
+			operation:       admissionv1.Create,
+			drainerConfig:   newTestDrainerConfig("node-1", "abc12", "https://api.abc12.example.com:443", "ip-10-1-2-3"),
+			expectedAllowed: true,
+		},
+		{
+			name:            "case 2: missing cluster ID is rejected",
+			operation:       admissionv1.Create,
+			drainerConfig:   newTestDrainerConfig("node-1", "", "api.abc12.example.com", "ip-10-1-2-3"),
+			expectedAllowed: false,
+		},
+		{
+			name:            "case 3: malformed endpoint is rejected",
+			operation:       admissionv1.Create,
+			drainerConfig:   newTestDrainerConfig("node-1", "abc12", "api abc12", "ip-10-1-2-3"),
+			expectedAllowed: false,
+		},
+		{
+			name:            "case 4: missing node name is rejected",
+			operation:       admissionv1.Create,
+			drainerConfig:   newTestDrainerConfig("node-1", "abc12", "api.abc12.example.com", ""),
+			expectedAllowed: false,
+		},
+		{
+			name:          "case 5: DrainerConfig for a node drained already is rejected",
+			operation:     admissionv1.Create,
+			drainerConfig: newTestDrainerConfig("node-1", "abc12", "api.abc12.example.com", "ip-10-1-2-3"),
+			existing: []v1alpha2.DrainerConfig{
+				newTestDrainerConfig("node-2", "abc12", "api.abc12.example.com", "ip-10-1-2-3"),
+			},
+			expectedAllowed: false,
+		},
+		{
+			name:          "case 6: DrainerConfig for the same node name in another cluster is allowed",
+			operation:     admissionv1.Create,
+			drainerConfig: newTestDrainerConfig("node-1", "abc12", "api.abc12.example.com", "ip-10-1-2-3"),
+			existing: []v1alpha2.DrainerConfig{
+				newTestDrainerConfig("node-2", "def34", "api.def34.example.com", "ip-10-1-2-3"),
+			},
+			expectedAllowed: true,
+		},
+		{
+			name:          "case 7: changing the node name is rejected",
+			operation:     admissionv1.Update,
+			drainerConfig: newTestDrainerConfig("node-1", "abc12", "api.abc12.example.com", "ip-10-1-2-4"),
+			old: func() *v1alpha2.DrainerConfig {
+				d := newTestDrainerConfig("node-1", "abc12", "api.abc12.example.com", "ip-10-1-2-3")
+				return &d
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:          "case 8: changing the policy is allowed",
+			operation:     admissionv1.Update,
+			drainerConfig: newTestDrainerConfig("node-1", "abc12", "api.abc12.example.com", "ip-10-1-2-3"),
+			old: func() *v1alpha2.DrainerConfig {
+				d := newTestDrainerConfig("node-1", "abc12", "api.abc12.example.com", "ip-10-1-2-3")
+				d.Spec.Policy = &v1alpha2.DrainerConfigSpecPolicy{Force: boolPtr(false)}
+				return &d
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "case 9: unknown delete action is rejected",
+			operation: admissionv1.Create,
+			drainerConfig: func() v1alpha2.DrainerConfig {
+				d := newTestDrainerConfig("node-1", "abc12", "api.abc12.example.com", "ip-10-1-2-3")
+				d.Spec.OnDelete = "Reboot"
+				return d
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "case 10: taint with an unknown effect is rejected",
+			operation: admissionv1.Create,
+			drainerConfig: func() v1alpha2.DrainerConfig {
+				d := newTestDrainerConfig("node-1", "abc12", "api.abc12.example.com", "ip-10-1-2-3")
+				d.Spec.Action = v1alpha2.ActionTaint
+				d.Spec.Taints = []corev1.Taint{{Key: "example.com/quarantined", Effect: "NoRun"}}
+				return d
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:          "case 11: changing the action is rejected",
+			operation:     admissionv1.Update,
+			drainerConfig: newTestDrainerConfig("node-1", "abc12", "api.abc12.example.com", "ip-10-1-2-3"),
+			old: func() *v1alpha2.DrainerConfig {
+				d := newTestDrainerConfig("node-1", "abc12", "api.abc12.example.com", "ip-10-1-2-3")
+				d.Spec.Action = v1alpha2.ActionCordon
+				return &d
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "case 12: negative retry backoff is rejected",
+			operation: admissionv1.Create,
+			drainerConfig: func() v1alpha2.DrainerConfig {
+				d := newTestDrainerConfig("node-1", "abc12", "api.abc12.example.com", "ip-10-1-2-3")
+				d.Spec.Retry = &v1alpha2.DrainerConfigSpecRetry{Backoff: &metav1.Duration{Duration: -time.Minute}, MaxAttempts: 3}
+				return d
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "case 13: negative escalation delay is rejected",
+			operation: admissionv1.Create,
+			drainerConfig: func() v1alpha2.DrainerConfig {
+				d := newTestDrainerConfig("node-1", "abc12", "api.abc12.example.com", "ip-10-1-2-3")
+				d.Spec.Escalation = &v1alpha2.DrainerConfigSpecEscalation{DeleteAfter: &metav1.Duration{Duration: -time.Minute}}
+				return d
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "case 14: negative volume detach timeout is rejected",
+			operation: admissionv1.Create,
+			drainerConfig: func() v1alpha2.DrainerConfig {
+				d := newTestDrainerConfig("node-1", "abc12", "api.abc12.example.com", "ip-10-1-2-3")
+				d.Spec.VolumeDetach = &v1alpha2.DrainerConfigSpecVolumeDetach{Timeout: &metav1.Duration{Duration: -time.Minute}}
+				return d
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:            "case 15: DrainerConfig referencing a Machine is allowed",
+			operation:       admissionv1.Create,
+			drainerConfig:   newTestMachineDrainerConfig("node-1", "abc12-md-0-x7k2p"),
+			expectedAllowed: true,
+		},
+		{
+			name:            "case 16: DrainerConfig for a Machine drained already is rejected",
+			operation:       admissionv1.Create,
+			drainerConfig:   newTestMachineDrainerConfig("node-1", "abc12-md-0-x7k2p"),
+			existing:        []v1alpha2.DrainerConfig{newTestMachineDrainerConfig("node-2", "abc12-md-0-x7k2p")},
+			expectedAllowed: false,
+		},
+		{
+			name:      "case 17: Cluster API DrainerConfig without node is rejected",
+			operation: admissionv1.Create,
+			drainerConfig: func() v1alpha2.DrainerConfig {
+				d := newTestDrainerConfig("node-1", "abc12", "", "")
+				d.Spec.ClusterAPI = &v1alpha2.DrainerConfigSpecClusterAPI{}
+				return d
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:          "case 18: changing the Machine is rejected",
+			operation:     admissionv1.Update,
+			drainerConfig: newTestMachineDrainerConfig("node-1", "abc12-md-0-x7k2p"),
+			old: func() *v1alpha2.DrainerConfig {
+				d := newTestMachineDrainerConfig("node-1", "abc12-md-0-b9c4d")
+				return &d
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "case 19: kubeconfig credentials without endpoint are allowed",
+			operation: admissionv1.Create,
+			drainerConfig: func() v1alpha2.DrainerConfig {
+				d := newTestDrainerConfig("node-1", "abc12", "", "ip-10-1-2-3")
+				d.Spec.WorkloadCluster.Credentials = &v1alpha2.DrainerConfigSpecWorkloadClusterCredentials{
+					Type:   v1alpha2.CredentialsTypeKubeconfig,
+					Secret: &v1alpha2.DrainerConfigSpecWorkloadClusterCredentialsSecret{Name: "abc12-kubeconfig", Key: "kubeconfig"},
+				}
+				return d
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "case 20: service account token credentials without secret are rejected",
+			operation: admissionv1.Create,
+			drainerConfig: func() v1alpha2.DrainerConfig {
+				d := newTestDrainerConfig("node-1", "abc12", "api.abc12.example.com", "ip-10-1-2-3")
+				d.Spec.WorkloadCluster.Credentials = &v1alpha2.DrainerConfigSpecWorkloadClusterCredentials{
+					Type: v1alpha2.CredentialsTypeServiceAccountToken,
+				}
+				return d
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "case 21: service account token credentials without endpoint are rejected",
+			operation: admissionv1.Create,
+			drainerConfig: func() v1alpha2.DrainerConfig {
+				d := newTestDrainerConfig("node-1", "abc12", "", "ip-10-1-2-3")
+				d.Spec.WorkloadCluster.Credentials = &v1alpha2.DrainerConfigSpecWorkloadClusterCredentials{
+					Type:   v1alpha2.CredentialsTypeServiceAccountToken,
+					Secret: &v1alpha2.DrainerConfigSpecWorkloadClusterCredentialsSecret{Name: "abc12-node-operator-token"},
+				}
+				return d
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "case 22: removing finalizers of a deleted DrainerConfig with an invalid spec is allowed",
+			operation: admissionv1.Update,
+			drainerConfig: func() v1alpha2.DrainerConfig {
+				d := newTestDrainerConfig("node-1", "abc12", "", "ip-10-1-2-3")
+				d.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+				return d
+			}(),
+			old: func() *v1alpha2.DrainerConfig {
+				d := newTestDrainerConfig("node-1", "abc12", "", "ip-10-1-2-3")
+				d.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+				d.Finalizers = []string{"operatorkit.giantswarm.io/node-operator-drainer-controller"}
+				return &d
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "case 23: changing labels of a DrainerConfig with an invalid spec is allowed",
+			operation: admissionv1.Update,
+			drainerConfig: func() v1alpha2.DrainerConfig {
+				d := newTestDrainerConfig("node-1", "abc12", "", "ip-10-1-2-3")
+				d.Labels = map[string]string{"app": "node-operator"}
+				return d
+			}(),
+			old: func() *v1alpha2.DrainerConfig {
+				d := newTestDrainerConfig("node-1", "abc12", "", "ip-10-1-2-3")
+				return &d
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "case 24: changing the spec to an invalid one is rejected",
+			operation: admissionv1.Update,
+			drainerConfig: func() v1alpha2.DrainerConfig {
+				d := newTestDrainerConfig("node-1", "abc12", "api.abc12.example.com", "ip-10-1-2-3")
+				d.Spec.Escalation = &v1alpha2.DrainerConfigSpecEscalation{DeleteAfter: &metav1.Duration{Duration: -time.Minute}}
+				return d
+			}(),
+			old: func() *v1alpha2.DrainerConfig {
+				d := newTestDrainerConfig("node-1", "abc12", "api.abc12.example.com", "ip-10-1-2-3")
+				return &d
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:          "case 25: DrainerConfig for a node drained by a completed DrainerConfig is allowed",
+			operation:     admissionv1.Create,
+			drainerConfig: newTestDrainerConfig("node-1", "abc12", "api.abc12.example.com", "ip-10-1-2-3"),
+			existing: func() []v1alpha2.DrainerConfig {
+				d := newTestDrainerConfig("node-2", "abc12", "api.abc12.example.com", "ip-10-1-2-3")
+				d.Status.Conditions = []metav1.Condition{d.Status.NewDrainedCondition("Drained", "")}
+				return []v1alpha2.DrainerConfig{d}
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:          "case 26: DrainerConfig for a node a timed out DrainerConfig gave up on is allowed",
+			operation:     admissionv1.Create,
+			drainerConfig: newTestDrainerConfig("node-1", "abc12", "api.abc12.example.com", "ip-10-1-2-3"),
+			existing: func() []v1alpha2.DrainerConfig {
+				d := newTestDrainerConfig("node-2", "abc12", "api.abc12.example.com", "ip-10-1-2-3")
+				d.Status.Conditions = []metav1.Condition{d.Status.NewTimeoutCondition("Timeout", "")}
+				return []v1alpha2.DrainerConfig{d}
+			}(),
+			expectedAllowed: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newTestService(t, tc.existing...)
+
+			request := &admissionv1.AdmissionRequest{
+				Object:    runtime.RawExtension{Raw: mustMarshal(t, tc.drainerConfig)},
+				Operation: tc.operation,
+			}
+			if tc.old != nil {
+				request.OldObject = runtime.RawExtension{Raw: mustMarshal(t, tc.old)}
+			}
+
+			response, err := s.Validate(context.Background(), request)
+			if err != nil {
+				t.Fatalf("error == %#v, want nil", err)
+			}
+
+			if response.Allowed != tc.expectedAllowed {
+				t.Fatalf("Allowed == %v, want %v (%v)", response.Allowed, tc.expectedAllowed, response.Result)
+			}
+		})
+	}
+}
+
+func Test_defaultPolicy(t *testing.T) {
+	testCases := []struct {
+		name           string
+		policy         *v1alpha2.DrainerConfigSpecPolicy
+		expectedPolicy *v1alpha2.DrainerConfigSpecPolicy
+	}{
+		{
+			name:   "case 0: nil policy gets the defaults shared by all kinds of nodes",
+			policy: nil,
+			expectedPolicy: &v1alpha2.DrainerConfigSpecPolicy{
+				DeleteEmptyDirData:              boolPtr(true),
+				DisableEviction:                 boolPtr(false),
+				Force:                           boolPtr(true),
+				SkipWaitForDeleteTimeoutSeconds: intPtr(15),
+			},
+		},
+		{
+			name: "case 1: fields set are kept",
+			policy: &v1alpha2.DrainerConfigSpecPolicy{
+				Force:              boolPtr(false),
+				GracePeriodSeconds: intPtr(10),
+			},
+			expectedPolicy: &v1alpha2.DrainerConfigSpecPolicy{
+				DeleteEmptyDirData:              boolPtr(true),
+				DisableEviction:                 boolPtr(false),
+				Force:                           boolPtr(false),
+				GracePeriodSeconds:              intPtr(10),
+				SkipWaitForDeleteTimeoutSeconds: intPtr(15),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			policy := defaultPolicy(testControlPlaneDrainPolicy, testWorkerDrainPolicy, tc.policy)
+
+			if string(mustMarshal(t, policy)) != string(mustMarshal(t, tc.expectedPolicy)) {
+				t.Fatalf("policy == %s, want %s", mustMarshal(t, policy), mustMarshal(t, tc.expectedPolicy))
+			}
+		})
+	}
+}
+
+func Test_Mutate(t *testing.T) {
+	s := newTestService(t)
+
+	drainerConfig := newTestDrainerConfig("node-1", "abc12", "api.abc12.example.com", "ip-10-1-2-3")
+	response, err := s.Mutate(context.Background(), &admissionv1.AdmissionRequest{
+		Object:    runtime.RawExtension{Raw: mustMarshal(t, drainerConfig)},
+		Operation: admissionv1.Create,
+	})
+	if err != nil {
+		t.Fatalf("error == %#v, want nil", err)
+	}
+
+	if !response.Allowed {
+		t.Fatalf("Allowed == false, want true")
+	}
+	if response.PatchType == nil || *response.PatchType != admissionv1.PatchTypeJSONPatch {
+		t.Fatalf("PatchType == %v, want %v", response.PatchType, admissionv1.PatchTypeJSONPatch)
+	}
+
+	var patch []map[string]interface{}
+	err = json.Unmarshal(response.Patch, &patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(patch) != 1 || patch[0]["path"] != "/spec/policy" {
+		t.Fatalf("patch == %s, want a single operation adding /spec/policy", response.Patch)
+	}
+}
+
+func newTestDrainerConfig(name, clusterID, endpoint, nodeName string) v1alpha2.DrainerConfig {
+	return v1alpha2.DrainerConfig{
+		TypeMeta: v1alpha2.NewDrainerTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: v1alpha2.DrainerConfigSpec{
+			Node: v1alpha2.DrainerConfigSpecNode{
+				Name: nodeName,
+			},
+			WorkloadCluster: v1alpha2.DrainerConfigSpecWorkloadCluster{
+				API: v1alpha2.DrainerConfigSpecWorkloadClusterAPI{
+					Endpoint: endpoint,
+				},
+				ID: clusterID,
+			},
+		},
+	}
+}
+
+func newTestMachineDrainerConfig(name, machine string) v1alpha2.DrainerConfig {
+	d := newTestDrainerConfig(name, "", "", "")
+	d.Spec.ClusterAPI = &v1alpha2.DrainerConfigSpecClusterAPI{
+		Machine: machine,
+	}
+
+	return d
+}
+
+func newTestService(t *testing.T, existing ...v1alpha2.DrainerConfig) *Service {
+	scheme := runtime.NewScheme()
+	err := v1alpha2.AddToScheme(scheme)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	for i := range existing {
+		builder = builder.WithObjects(&existing[i])
+	}
+
+	s, err := New(Config{
+		K8sClient: k8sclienttest.NewClients(k8sclienttest.ClientsConfig{
+			CtrlClient: builder.Build(),
+		}),
+		Logger: microloggertest.New(),
+
+		ControlPlaneDrainPolicy: testControlPlaneDrainPolicy,
+		WorkerDrainPolicy:       testWorkerDrainPolicy,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return s
+}
+
+func mustMarshal(t *testing.T, v interface{}) []byte {
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func intPtr(i int) *int {
+	return &i
+}
@@ -0,0 +1,21 @@
+package admission
+
+import "github.com/giantswarm/microerror"
+
+var invalidConfigError = &microerror.Error{
+	Kind: "invalidConfigError",
+}
+
+// IsInvalidConfig asserts invalidConfigError.
+func IsInvalidConfig(err error) bool {
+	return microerror.Cause(err) == invalidConfigError
+}
+
+var unsupportedVersionError = &microerror.Error{
+	Kind: "unsupportedVersionError",
+}
+
+// IsUnsupportedVersion asserts unsupportedVersionError.
+func IsUnsupportedVersion(err error) bool {
+	return microerror.Cause(err) == unsupportedVersionError
+}
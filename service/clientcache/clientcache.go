@@ -0,0 +1,262 @@
+// Package clientcache caches the clients of workload clusters. Creating a
+// client looks up credentials and runs discovery against the workload cluster
+// API, which is too expensive to do in every reconciliation of every
+// DrainerConfig. Clients are dropped once their TTL or their client
+// certificate expires, or as soon as the workload cluster API rejects their
+// credentials or cannot be verified anymore, e.g. after a certificate
+// rotation.
+package clientcache
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/giantswarm/k8sclient/v7/pkg/k8sclient"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+	"github.com/giantswarm/node-operator/service/controller/key"
+	"github.com/giantswarm/node-operator/service/credential"
+)
+
+type Config struct {
+	Credentials credential.Interface
+	Logger      micrologger.Logger
+
+	// TTL is the maximum amount of time a client is reused. Zero disables
+	// caching.
+	TTL time.Duration
+}
+
+type Cache struct {
+	credentials credential.Interface
+	logger      micrologger.Logger
+
+	ttl time.Duration
+
+	mutex   sync.Mutex
+	entries map[cacheKey]*entry
+
+	// newK8sClient and now are replaced in tests.
+	newK8sClient func(restConfig *rest.Config) (kubernetes.Interface, error)
+	now          func() time.Time
+}
+
+// cacheKey identifies the workload cluster and the credentials a client is
+// created for.
+type cacheKey struct {
+	Namespace       string
+	Cluster         string
+	Endpoint        string
+	CredentialsType string
+	SecretName      string
+	SecretKey       string
+}
+
+type entry struct {
+	k8sClient kubernetes.Interface
+	expires   time.Time
+}
+
+func New(c Config) (*Cache, error) {
+	if c.Credentials == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Credentials must not be empty", c)
+	}
+	if c.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", c)
+	}
+
+	if c.TTL < 0 {
+		return nil, microerror.Maskf(invalidConfigError, "%T.TTL must not be negative", c)
+	}
+
+	cache := &Cache{
+		credentials: c.Credentials,
+		logger:      c.Logger,
+
+		ttl: c.TTL,
+
+		entries: map[cacheKey]*entry{},
+
+		now: time.Now,
+	}
+	cache.newK8sClient = cache.newClients
+
+	return cache, nil
+}
+
+// K8sClient returns the cached client of the workload cluster of the given
+// DrainerConfig, or creates one. Clients of DrainerConfigs reconciled at the
+// same time may be created more than once, in which case the last one created
+// is kept.
+func (c *Cache) K8sClient(ctx context.Context, drainerConfig v1alpha2.DrainerConfig) (kubernetes.Interface, error) {
+	k := newCacheKey(drainerConfig)
+
+	if k8sClient, ok := c.get(k); ok {
+		hitCounter.Inc()
+		return k8sClient, nil
+	}
+	missCounter.Inc()
+
+	restConfig, err := c.credentials.NewRestConfig(ctx, drainerConfig)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	if c.ttl == 0 {
+		k8sClient, err := c.newK8sClient(restConfig)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		return k8sClient, nil
+	}
+
+	e := &entry{
+		expires: c.now().Add(c.ttl),
+	}
+	if notAfter, ok := certificateNotAfter(restConfig); ok && notAfter.Before(e.expires) {
+		e.expires = notAfter
+	}
+
+	restConfig = rest.CopyConfig(restConfig)
+	restConfig.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &invalidatingRoundTripper{
+			next: rt,
+			invalidate: func(ctx context.Context, reason string) {
+				c.invalidate(ctx, k, e, reason)
+			},
+		}
+	})
+
+	e.k8sClient, err = c.newK8sClient(restConfig)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	c.mutex.Lock()
+	c.entries[k] = e
+	entriesGauge.Set(float64(len(c.entries)))
+	c.mutex.Unlock()
+
+	return e.k8sClient, nil
+}
+
+func (c *Cache) get(k cacheKey) (kubernetes.Interface, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	e, ok := c.entries[k]
+	if !ok {
+		return nil, false
+	}
+
+	if !c.now().Before(e.expires) {
+		delete(c.entries, k)
+		entriesGauge.Set(float64(len(c.entries)))
+		invalidationCounter.WithLabelValues(reasonExpired).Inc()
+
+		return nil, false
+	}
+
+	return e.k8sClient, true
+}
+
+// invalidate drops the given entry, unless it got replaced already.
+func (c *Cache) invalidate(ctx context.Context, k cacheKey, e *entry, reason string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.entries[k] != e {
+		return
+	}
+
+	delete(c.entries, k)
+	entriesGauge.Set(float64(len(c.entries)))
+	invalidationCounter.WithLabelValues(reason).Inc()
+
+	c.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("dropped client of workload cluster %s from the cache due to %s", k.Cluster, reason))
+}
+
+func newCacheKey(drainerConfig v1alpha2.DrainerConfig) cacheKey {
+	return cacheKey{
+		Namespace:       drainerConfig.Namespace,
+		Cluster:         key.ClusterIDFromDrainerConfig(drainerConfig),
+		Endpoint:        key.ClusterEndpointFromDrainerConfig(drainerConfig),
+		CredentialsType: key.CredentialsTypeFromDrainerConfig(drainerConfig),
+		SecretName:      key.CredentialsSecretNameFromDrainerConfig(drainerConfig),
+		SecretKey:       key.CredentialsSecretKeyFromDrainerConfig(drainerConfig),
+	}
+}
+
+func (c *Cache) newClients(restConfig *rest.Config) (kubernetes.Interface, error) {
+	config := k8sclient.ClientsConfig{
+		Logger:     c.logger,
+		RestConfig: restConfig,
+	}
+
+	k8sClients, err := k8sclient.NewClients(config)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return k8sClients.K8sClient(), nil
+}
+
+// certificateNotAfter returns the expiry of the client certificate of the
+// given config, if it uses one.
+func certificateNotAfter(restConfig *rest.Config) (time.Time, bool) {
+	block, _ := pem.Decode(restConfig.CertData)
+	if block == nil {
+		return time.Time{}, false
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return cert.NotAfter, true
+}
+
+// invalidatingRoundTripper invalidates the cached client it belongs to when
+// the workload cluster API rejects its credentials or the certificate of the
+// workload cluster API cannot be verified.
+type invalidatingRoundTripper struct {
+	next       http.RoundTripper
+	invalidate func(ctx context.Context, reason string)
+}
+
+func (rt *invalidatingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := rt.next.RoundTrip(req)
+	if isCertificateError(err) {
+		rt.invalidate(req.Context(), reasonCertificateError)
+	} else if err == nil && resp.StatusCode == http.StatusUnauthorized {
+		rt.invalidate(req.Context(), reasonAuthError)
+	}
+
+	return resp, err
+}
+
+func isCertificateError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var verificationErr *tls.CertificateVerificationError
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var invalidErr x509.CertificateInvalidError
+	var hostnameErr x509.HostnameError
+
+	return errors.As(err, &verificationErr) || errors.As(err, &unknownAuthorityErr) || errors.As(err, &invalidErr) || errors.As(err, &hostnameErr)
+}
@@ -0,0 +1,145 @@
+package clientcache
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/giantswarm/micrologger/microloggertest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+)
+
+type testCredentials struct {
+	host  string
+	calls int
+}
+
+func (c *testCredentials) NewRestConfig(ctx context.Context, drainerConfig v1alpha2.DrainerConfig) (*rest.Config, error) {
+	c.calls++
+	return &rest.Config{Host: c.host}, nil
+}
+
+func newTestCache(t *testing.T, credentials *testCredentials, ttl time.Duration) *Cache {
+	c, err := New(Config{
+		Credentials: credentials,
+		Logger:      microloggertest.New(),
+
+		TTL: ttl,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Creating clients must not run discovery against the fake API
+	c.newK8sClient = func(restConfig *rest.Config) (kubernetes.Interface, error) {
+		return kubernetes.NewForConfig(restConfig)
+	}
+
+	return c
+}
+
+func newTestDrainerConfig(cluster string) v1alpha2.DrainerConfig {
+	return v1alpha2.DrainerConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "ip-10-1-2-3", Namespace: "org-example"},
+		Spec: v1alpha2.DrainerConfigSpec{
+			WorkloadCluster: v1alpha2.DrainerConfigSpecWorkloadCluster{
+				API: v1alpha2.DrainerConfigSpecWorkloadClusterAPI{Endpoint: "api." + cluster + ".example.com"},
+				ID:  cluster,
+			},
+		},
+	}
+}
+
+func Test_Cache_K8sClient(t *testing.T) {
+	testCases := []struct {
+		name          string
+		ttl           time.Duration
+		clusters      []string
+		elapsed       time.Duration
+		expectedCalls int
+	}{
+		{
+			name:          "case 0: client is reused within the TTL",
+			ttl:           10 * time.Minute,
+			clusters:      []string{"abc12", "abc12"},
+			elapsed:       5 * time.Minute,
+			expectedCalls: 1,
+		},
+		{
+			name:          "case 1: client is created again once the TTL elapsed",
+			ttl:           10 * time.Minute,
+			clusters:      []string{"abc12", "abc12"},
+			elapsed:       10 * time.Minute,
+			expectedCalls: 2,
+		},
+		{
+			name:          "case 2: clients are cached per cluster",
+			ttl:           10 * time.Minute,
+			clusters:      []string{"abc12", "def34", "abc12"},
+			expectedCalls: 2,
+		},
+		{
+			name:          "case 3: zero TTL disables caching",
+			ttl:           0,
+			clusters:      []string{"abc12", "abc12"},
+			expectedCalls: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			credentials := &testCredentials{host: "https://127.0.0.1:6443"}
+			c := newTestCache(t, credentials, tc.ttl)
+
+			now := time.Now()
+			c.now = func() time.Time { return now }
+
+			for _, cluster := range tc.clusters {
+				_, err := c.K8sClient(context.Background(), newTestDrainerConfig(cluster))
+				if err != nil {
+					t.Fatalf("error == %#v, want nil", err)
+				}
+				now = now.Add(tc.elapsed)
+			}
+
+			if credentials.calls != tc.expectedCalls {
+				t.Fatalf("calls == %d, want %d", credentials.calls, tc.expectedCalls)
+			}
+		})
+	}
+}
+
+func Test_Cache_K8sClient_Unauthorized(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	credentials := &testCredentials{host: server.URL}
+	c := newTestCache(t, credentials, 10*time.Minute)
+
+	k8sClient, err := c.K8sClient(context.Background(), newTestDrainerConfig("abc12"))
+	if err != nil {
+		t.Fatalf("error == %#v, want nil", err)
+	}
+
+	_, err = k8sClient.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{})
+	if err == nil {
+		t.Fatalf("error == nil, want non-nil")
+	}
+
+	_, err = c.K8sClient(context.Background(), newTestDrainerConfig("abc12"))
+	if err != nil {
+		t.Fatalf("error == %#v, want nil", err)
+	}
+
+	if credentials.calls != 2 {
+		t.Fatalf("calls == %d, want 2", credentials.calls)
+	}
+}
@@ -0,0 +1,67 @@
+package clientcache
+
+import "github.com/prometheus/client_golang/prometheus"
+
+const (
+	PrometheusNamespace = "node_operator"
+	PrometheusSubsystem = "client_cache"
+)
+
+const (
+	// reasonAuthError means a request of the client failed authentication,
+	// e.g. because the credentials got revoked.
+	reasonAuthError = "auth_error"
+	// reasonCertificateError means the client could not verify the
+	// certificate of the workload cluster API, e.g. because its CA got
+	// rotated.
+	reasonCertificateError = "certificate_error"
+	// reasonExpired means the TTL of the client elapsed or its client
+	// certificate expired.
+	reasonExpired = "expired"
+)
+
+var (
+	hitCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: PrometheusSubsystem,
+			Name:      "hits_total",
+			Help:      "Number of workload cluster clients served from the cache.",
+		},
+	)
+
+	missCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: PrometheusSubsystem,
+			Name:      "misses_total",
+			Help:      "Number of workload cluster clients created because none was cached.",
+		},
+	)
+
+	invalidationCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: PrometheusSubsystem,
+			Name:      "invalidations_total",
+			Help:      "Number of cached workload cluster clients dropped.",
+		},
+		[]string{"reason"},
+	)
+
+	entriesGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Subsystem: PrometheusSubsystem,
+			Name:      "entries",
+			Help:      "Number of cached workload cluster clients.",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(hitCounter)
+	prometheus.MustRegister(missCounter)
+	prometheus.MustRegister(invalidationCounter)
+	prometheus.MustRegister(entriesGauge)
+}
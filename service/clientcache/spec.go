@@ -0,0 +1,16 @@
+package clientcache
+
+import (
+	"context"
+
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+)
+
+type Interface interface {
+	// K8sClient returns a client of the workload cluster of the given
+	// DrainerConfig. Clients are shared by all DrainerConfigs of a workload
+	// cluster accessed with the same credentials.
+	K8sClient(ctx context.Context, drainerConfig v1alpha2.DrainerConfig) (kubernetes.Interface, error)
+}
@@ -12,16 +12,27 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	v1alpha1 "github.com/giantswarm/node-operator/api"
+	"github.com/giantswarm/node-operator/api/v1alpha2"
 	"github.com/giantswarm/node-operator/pkg/project"
 	"github.com/giantswarm/node-operator/service/controller/key"
+	"github.com/giantswarm/node-operator/service/controller/resource/drainer"
+	"github.com/giantswarm/node-operator/service/eventtarget"
+	"github.com/giantswarm/node-operator/service/executor"
 	event "github.com/giantswarm/node-operator/service/recorder"
 )
 
 type DrainerConfig struct {
-	Event     event.Interface
-	K8sClient k8sclient.Interface
-	Logger    micrologger.Logger
+	Event       event.Interface
+	EventTarget eventtarget.Interface
+	Executor    *executor.Executor
+	K8sClient   k8sclient.Interface
+	Logger      micrologger.Logger
+
+	// ClientCacheTTL is the maximum amount of time a workload cluster client
+	// is reused.
+	ClientCacheTTL          time.Duration
+	ControlPlaneDrainPolicy drainer.DrainPolicy
+	WorkerDrainPolicy       drainer.DrainPolicy
 }
 
 type Drainer struct {
@@ -70,7 +81,7 @@ func NewDrainer(config DrainerConfig) (*Drainer, error) {
 			Resources:    resources,
 			ResyncPeriod: 1 * time.Minute,
 			NewRuntimeObjectFunc: func() client.Object {
-				return new(v1alpha1.DrainerConfig)
+				return new(v1alpha2.DrainerConfig)
 			},
 			Selector: selector,
 
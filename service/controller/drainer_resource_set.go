@@ -12,14 +12,26 @@ import (
 	"github.com/giantswarm/operatorkit/v7/pkg/resource/wrapper/retryresource"
 	"github.com/giantswarm/tenantcluster/v6/pkg/tenantcluster"
 
+	"github.com/giantswarm/node-operator/service/clientcache"
 	"github.com/giantswarm/node-operator/service/controller/resource/drainer"
+	"github.com/giantswarm/node-operator/service/credential"
+	"github.com/giantswarm/node-operator/service/eventtarget"
+	"github.com/giantswarm/node-operator/service/executor"
 	event "github.com/giantswarm/node-operator/service/recorder"
 )
 
 type DrainerResourceSetConfig struct {
-	Event     event.Interface
-	K8sClient k8sclient.Interface
-	Logger    micrologger.Logger
+	Event       event.Interface
+	EventTarget eventtarget.Interface
+	Executor    *executor.Executor
+	K8sClient   k8sclient.Interface
+	Logger      micrologger.Logger
+
+	// ClientCacheTTL is the maximum amount of time a workload cluster client
+	// is reused.
+	ClientCacheTTL          time.Duration
+	ControlPlaneDrainPolicy drainer.DrainPolicy
+	WorkerDrainPolicy       drainer.DrainPolicy
 }
 
 func NewDrainerResourceSet(config DrainerResourceSetConfig) ([]resource.Interface, error) {
@@ -55,15 +67,48 @@ func NewDrainerResourceSet(config DrainerResourceSetConfig) ([]resource.Interfac
 		}
 	}
 
-	var drainerResource resource.Interface
+	var credentials credential.Interface
 	{
-		c := drainer.Config{
-			Event:         config.Event,
+		c := credential.Config{
 			Client:        config.K8sClient.CtrlClient(),
-			Logger:        config.Logger,
 			TenantCluster: tenantCluster,
 		}
 
+		credentials, err = credential.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var clientCache clientcache.Interface
+	{
+		c := clientcache.Config{
+			Credentials: credentials,
+			Logger:      config.Logger,
+
+			TTL: config.ClientCacheTTL,
+		}
+
+		clientCache, err = clientcache.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var drainerResource resource.Interface
+	{
+		c := drainer.Config{
+			Event:       config.Event,
+			EventTarget: config.EventTarget,
+			Client:      config.K8sClient.CtrlClient(),
+			ClientCache: clientCache,
+			Executor:    config.Executor,
+			Logger:      config.Logger,
+
+			ControlPlaneDrainPolicy: config.ControlPlaneDrainPolicy,
+			WorkerDrainPolicy:       config.WorkerDrainPolicy,
+		}
+
 		drainerResource, err = drainer.New(c)
 		if err != nil {
 			return nil, microerror.Mask(err)
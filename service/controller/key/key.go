@@ -1,31 +1,196 @@
 package key
 
 import (
+	"time"
+
 	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
-	v1alpha1 "github.com/giantswarm/node-operator/api"
+	"github.com/giantswarm/node-operator/api/v1alpha2"
 )
 
 const (
 	LabelNodeOperatorVersion = "node-operator.giantswarm.io/version"
 )
 
-func ClusterEndpointFromDrainerConfig(drainerConfig v1alpha1.DrainerConfig) string {
-	return drainerConfig.Spec.Guest.Cluster.API.Endpoint
+// defaultKubeconfigSecretKey is the key of the kubeconfig in the kubeconfig
+// Secrets of Cluster API Clusters.
+const defaultKubeconfigSecretKey = "value"
+
+const (
+	// TaintQuarantined is the key of the taint added to nodes by the Taint
+	// action when the DrainerConfig does not specify its own taints.
+	TaintQuarantined = "node-operator.giantswarm.io/quarantined"
+)
+
+// ClusterAPIGroupVersion is the API version Cluster API objects are read
+// with. They are read as unstructured objects, so that the operator does not
+// depend on a particular Cluster API release.
+var ClusterAPIGroupVersion = schema.GroupVersion{
+	Group:   "cluster.x-k8s.io",
+	Version: "v1beta1",
 }
 
-func ClusterIDFromDrainerConfig(drainerConfig v1alpha1.DrainerConfig) string {
-	return drainerConfig.Spec.Guest.Cluster.ID
+// ActionFromDrainerConfig returns what is done to the node of the given
+// DrainerConfig, defaulting to draining it.
+func ActionFromDrainerConfig(drainerConfig v1alpha2.DrainerConfig) string {
+	if drainerConfig.Spec.Action == "" {
+		return v1alpha2.ActionDrain
+	}
+
+	return drainerConfig.Spec.Action
 }
 
-func NodeNameFromDrainerConfig(drainerConfig v1alpha1.DrainerConfig) string {
-	return drainerConfig.Spec.Guest.Node.Name
+func ClusterEndpointFromDrainerConfig(drainerConfig v1alpha2.DrainerConfig) string {
+	return drainerConfig.Spec.WorkloadCluster.API.Endpoint
+}
+
+func ClusterIDFromDrainerConfig(drainerConfig v1alpha2.DrainerConfig) string {
+	return drainerConfig.Spec.WorkloadCluster.ID
+}
+
+// ClusterAPIKubeconfigSecretName returns the name of the Secret holding the
+// kubeconfig of the given Cluster API Cluster.
+func ClusterAPIKubeconfigSecretName(clusterName string) string {
+	return clusterName + "-kubeconfig"
+}
+
+// CredentialsSecretKeyFromDrainerConfig returns the key of the kubeconfig in
+// the credentials Secret of the given DrainerConfig, defaulting to the key
+// Cluster API uses.
+func CredentialsSecretKeyFromDrainerConfig(drainerConfig v1alpha2.DrainerConfig) string {
+	c := drainerConfig.Spec.WorkloadCluster.Credentials
+	if c == nil || c.Secret == nil || c.Secret.Key == "" {
+		return defaultKubeconfigSecretKey
+	}
+
+	return c.Secret.Key
+}
+
+// CredentialsSecretNameFromDrainerConfig returns the name of the Secret
+// holding the credentials for the workload cluster of the given
+// DrainerConfig. Kubeconfig credentials default to the kubeconfig Secret of
+// the Cluster API Cluster.
+func CredentialsSecretNameFromDrainerConfig(drainerConfig v1alpha2.DrainerConfig) string {
+	c := drainerConfig.Spec.WorkloadCluster.Credentials
+	if c != nil && c.Secret != nil && c.Secret.Name != "" {
+		return c.Secret.Name
+	}
+	if CredentialsTypeFromDrainerConfig(drainerConfig) == v1alpha2.CredentialsTypeKubeconfig {
+		return ClusterAPIKubeconfigSecretName(ClusterIDFromDrainerConfig(drainerConfig))
+	}
+
+	return ""
+}
+
+// CredentialsTypeFromDrainerConfig returns the kind of credentials the
+// workload cluster of the given DrainerConfig is accessed with, defaulting to
+// the kubeconfig Secret for Cluster API workload clusters and to certificates
+// otherwise.
+func CredentialsTypeFromDrainerConfig(drainerConfig v1alpha2.DrainerConfig) string {
+	c := drainerConfig.Spec.WorkloadCluster.Credentials
+	if c != nil && c.Type != "" {
+		return c.Type
+	}
+	if IsClusterAPI(drainerConfig) {
+		return v1alpha2.CredentialsTypeKubeconfig
+	}
+
+	return v1alpha2.CredentialsTypeCerts
+}
+
+// EscalateToDeleteAfterFromDrainerConfig returns the number of failed
+// attempts after which pods of the node of the given DrainerConfig are deleted
+// instead of evicted. Zero means never.
+func EscalateToDeleteAfterFromDrainerConfig(drainerConfig v1alpha2.DrainerConfig) int {
+	if drainerConfig.Spec.Retry == nil {
+		return 0
+	}
+
+	return drainerConfig.Spec.Retry.EscalateToDeleteAfter
+}
+
+// MaxAttemptsFromDrainerConfig returns the maximum number of attempts to drain
+// the node of the given DrainerConfig, defaulting to a single attempt.
+func MaxAttemptsFromDrainerConfig(drainerConfig v1alpha2.DrainerConfig) int {
+	if drainerConfig.Spec.Retry == nil || drainerConfig.Spec.Retry.MaxAttempts < 1 {
+		return 1
+	}
+
+	return drainerConfig.Spec.Retry.MaxAttempts
+}
+
+// IsClusterAPI checks whether the workload cluster of the given DrainerConfig
+// is accessed the Cluster API way.
+func IsClusterAPI(drainerConfig v1alpha2.DrainerConfig) bool {
+	return drainerConfig.Spec.ClusterAPI != nil
+}
+
+// MachineFromDrainerConfig returns the name of the Cluster API Machine whose
+// node the given DrainerConfig drains, if any.
+func MachineFromDrainerConfig(drainerConfig v1alpha2.DrainerConfig) string {
+	if drainerConfig.Spec.ClusterAPI == nil {
+		return ""
+	}
+
+	return drainerConfig.Spec.ClusterAPI.Machine
+}
+
+func NodeNameFromDrainerConfig(drainerConfig v1alpha2.DrainerConfig) string {
+	return drainerConfig.Spec.Node.Name
+}
+
+// OnDeleteFromDrainerConfig returns what happens to the node when the given
+// DrainerConfig is deleted, defaulting to deleting the node.
+func OnDeleteFromDrainerConfig(drainerConfig v1alpha2.DrainerConfig) string {
+	if drainerConfig.Spec.OnDelete == "" {
+		return v1alpha2.OnDeleteDeleteNode
+	}
+
+	return drainerConfig.Spec.OnDelete
+}
+
+// RetryBackoffFromDrainerConfig returns the time waited before the first
+// retry of a failed drain of the given DrainerConfig, defaulting to a minute.
+func RetryBackoffFromDrainerConfig(drainerConfig v1alpha2.DrainerConfig) time.Duration {
+	if drainerConfig.Spec.Retry == nil || drainerConfig.Spec.Retry.Backoff == nil || drainerConfig.Spec.Retry.Backoff.Duration <= 0 {
+		return time.Minute
+	}
+
+	return drainerConfig.Spec.Retry.Backoff.Duration
+}
+
+// TaintsFromDrainerConfig returns the taints added to the node of the given
+// DrainerConfig when it gets cordoned.
+func TaintsFromDrainerConfig(drainerConfig v1alpha2.DrainerConfig) []corev1.Taint {
+	if len(drainerConfig.Spec.Taints) == 0 && ActionFromDrainerConfig(drainerConfig) == v1alpha2.ActionTaint {
+		return []corev1.Taint{
+			{
+				Effect: corev1.TaintEffectNoSchedule,
+				Key:    TaintQuarantined,
+			},
+		}
+	}
+
+	return drainerConfig.Spec.Taints
+}
+
+// VolumeDetachTimeoutFromDrainerConfig returns the maximum time waited for the
+// volumes of the node of the given DrainerConfig to be detached, defaulting to
+// five minutes.
+func VolumeDetachTimeoutFromDrainerConfig(drainerConfig v1alpha2.DrainerConfig) time.Duration {
+	if drainerConfig.Spec.VolumeDetach == nil || drainerConfig.Spec.VolumeDetach.Timeout == nil || drainerConfig.Spec.VolumeDetach.Timeout.Duration <= 0 {
+		return 5 * time.Minute
+	}
+
+	return drainerConfig.Spec.VolumeDetach.Timeout.Duration
 }
 
-func ToDrainerConfig(v interface{}) (v1alpha1.DrainerConfig, error) {
-	p, ok := v.(*v1alpha1.DrainerConfig)
+func ToDrainerConfig(v interface{}) (v1alpha2.DrainerConfig, error) {
+	p, ok := v.(*v1alpha2.DrainerConfig)
 	if !ok {
-		return v1alpha1.DrainerConfig{}, microerror.Maskf(wrongTypeError, "expected '%T', got '%T'", &v1alpha1.DrainerConfig{}, v)
+		return v1alpha2.DrainerConfig{}, microerror.Maskf(wrongTypeError, "expected '%T', got '%T'", &v1alpha2.DrainerConfig{}, v)
 	}
 	o := *p
 
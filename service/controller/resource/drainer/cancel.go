@@ -0,0 +1,122 @@
+package drainer
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/giantswarm/microerror"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/kubectl/pkg/drain"
+
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+	"github.com/giantswarm/node-operator/service/controller/key"
+	"github.com/giantswarm/node-operator/service/executor"
+)
+
+// Cancels the drain requested by setting spec.cancel and reports it in the
+// status. The node gets uncordoned if spec.uncordonOnCancel is set.
+func (r *Resource) cancelDrain(ctx context.Context,
+	eventTarget pkgruntime.Object,
+	k8sClient kubernetes.Interface,
+	drainerConfig v1alpha2.DrainerConfig) error {
+
+	nodeName := key.NodeNameFromDrainerConfig(drainerConfig)
+
+	if drainerConfig.Status.IsDrainCanceled() {
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("drain of node %s got canceled already", nodeName))
+		r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
+
+		return nil
+	}
+
+	// We get reconciled again as soon as the drain stopped
+	if !r.stopDrain(drainerConfig) {
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("waiting for drain of node %s to stop", nodeName))
+		r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
+
+		return nil
+	}
+
+	if drainerConfig.Spec.UncordonOnCancel {
+		err := r.uncordon(ctx, k8sClient, nodeName, key.TaintsFromDrainerConfig(drainerConfig))
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	r.logger.LogCtx(ctx, "level", "info", "message", fmt.Sprintf("canceled drain of node %s", nodeName))
+	r.event.Info(ctx, eventTarget, "DrainingCanceled", fmt.Sprintf("canceled drain of node %s", nodeName))
+
+	message := fmt.Sprintf("Drain of node %s got canceled", nodeName)
+	return r.updateDrainerStatusFunc(ctx, drainerConfig,
+		func(s *v1alpha2.DrainerConfigStatus) {
+			if s.Drain == nil {
+				s.Drain = &v1alpha2.DrainerConfigStatusDrain{
+					StartTime: metav1.Now(),
+				}
+			}
+			s.Drain.Phase = v1alpha2.DrainPhaseCanceled
+		},
+		falseCondition(drainerConfig.Status.NewPendingCondition(v1alpha2.ReasonCanceled, message)),
+		falseCondition(drainerConfig.Status.NewDrainingCondition(v1alpha2.ReasonCanceled, message)),
+		drainerConfig.Status.NewFailedCondition(v1alpha2.ReasonCanceled, message),
+	)
+}
+
+// Cancels the drain of the given drainer config in the executor and removes
+// it from there. It returns false while the drain is still running, in which
+// case the drainer config gets requeued once it stopped.
+func (r *Resource) stopDrain(drainerConfig v1alpha2.DrainerConfig) bool {
+	id := drainTaskID(drainerConfig)
+
+	r.executor.Cancel(id)
+
+	state, _ := r.executor.Result(id)
+	if state == executor.StateRunning {
+		return false
+	}
+
+	r.executor.Forget(id)
+
+	return true
+}
+
+// Uncordons the given node, if it still exists, and removes the given taints
+// from it
+func (r *Resource) uncordon(ctx context.Context, k8sClient kubernetes.Interface, nodeName string, taints []v1.Taint) error {
+	node, err := k8sClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("did not uncordon node %s", nodeName))
+		r.logger.LogCtx(ctx, "level", "debug", "message", "tenant cluster node not found")
+
+		return nil
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	h := drain.Helper{
+		Ctx:    ctx,
+		Client: k8sClient,
+		Out:    os.Stdout,
+		ErrOut: os.Stderr,
+	}
+
+	err = drain.RunCordonOrUncordon(&h, node, false)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = r.untaintNode(ctx, k8sClient, nodeName, taints)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("uncordoned node %s", nodeName))
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package drainer
+
+import (
+	"context"
+
+	infrastructurev1alpha3 "github.com/giantswarm/apiextensions/v6/pkg/apis/infrastructure/v1alpha3"
+	"github.com/giantswarm/microerror"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	pkgruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+	"github.com/giantswarm/node-operator/service/controller/key"
+)
+
+// Resolves the node and the workload cluster of drainer configs referencing a
+// Cluster API Machine. They are set in the spec of the returned copy of the
+// drainer config, so that the rest of the resource does not need to tell the
+// ways of referencing nodes apart. Other drainer configs are returned as they
+// are.
+func (r *Resource) resolveMachine(ctx context.Context, drainerConfig v1alpha2.DrainerConfig) (v1alpha2.DrainerConfig, error) {
+	name := key.MachineFromDrainerConfig(drainerConfig)
+	if name == "" {
+		return drainerConfig, nil
+	}
+
+	machine := &unstructured.Unstructured{}
+	machine.SetGroupVersionKind(key.ClusterAPIGroupVersion.WithKind("Machine"))
+
+	err := r.client.Get(ctx, types.NamespacedName{Name: name, Namespace: drainerConfig.Namespace}, machine)
+	if apierrors.IsNotFound(err) {
+		return drainerConfig, microerror.Maskf(machineNotFoundError, "machine %s/%s", drainerConfig.Namespace, name)
+	} else if err != nil {
+		return drainerConfig, microerror.Mask(err)
+	}
+
+	cluster, node := machineNode(machine)
+	if node == "" {
+		return drainerConfig, microerror.Maskf(nodeRefMissingError, "machine %s/%s", drainerConfig.Namespace, name)
+	}
+
+	drainerConfig.Spec.Node.Name = node
+	if cluster != "" {
+		drainerConfig.Spec.WorkloadCluster.ID = cluster
+	}
+
+	return drainerConfig, nil
+}
+
+// Checks whether the workload cluster of the given drainer config got
+// deleted. Like the event target, the workload cluster is looked up as
+// AWSCluster and as Cluster API Cluster, or only as the latter for drainer
+// configs referencing a Machine. It only counts as deleted once none of the
+// kinds known to the management cluster has it, so that the finalizers are
+// kept when no kind tells about it, e.g. because their CRDs are missing.
+func (r *Resource) clusterDeleted(ctx context.Context, drainerConfig v1alpha2.DrainerConfig) (bool, error) {
+	var candidates []client.Object
+	if !key.IsClusterAPI(drainerConfig) {
+		candidates = append(candidates, &infrastructurev1alpha3.AWSCluster{})
+	}
+	{
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(key.ClusterAPIGroupVersion.WithKind("Cluster"))
+		candidates = append(candidates, u)
+	}
+
+	var notFound bool
+	for _, cluster := range candidates {
+		err := r.client.Get(ctx, types.NamespacedName{Name: key.ClusterIDFromDrainerConfig(drainerConfig), Namespace: drainerConfig.Namespace}, cluster)
+		if apierrors.IsNotFound(err) {
+			notFound = true
+			continue
+		} else if isKindUnknown(err) {
+			continue
+		} else if err != nil {
+			return false, microerror.Mask(err)
+		}
+
+		return false, nil
+	}
+
+	if !notFound {
+		r.logger.LogCtx(ctx, "level", "debug", "message", "management cluster knows no kind of workload cluster object")
+	}
+
+	return notFound, nil
+}
+
+// isKindUnknown checks whether the given error tells that the kind of the
+// requested object does not exist in the management cluster, e.g. because
+// the provider does not use AWSClusters or Cluster API is not installed.
+func isKindUnknown(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	cause := microerror.Cause(err)
+
+	return meta.IsNoMatchError(cause) || pkgruntime.IsNotRegisteredError(cause)
+}
+
+// machineNode returns the name of the workload cluster and the name of the
+// node of the given Cluster API Machine. The node name is empty as long as
+// the Machine has no node.
+func machineNode(machine *unstructured.Unstructured) (string, string) {
+	cluster, _, _ := unstructured.NestedString(machine.Object, "spec", "clusterName")
+	node, _, _ := unstructured.NestedString(machine.Object, "status", "nodeRef", "name")
+
+	return cluster, node
+}
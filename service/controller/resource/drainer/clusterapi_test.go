@@ -0,0 +1,167 @@
+package drainer
+
+import (
+	"context"
+	"testing"
+
+	infrastructurev1alpha3 "github.com/giantswarm/apiextensions/v6/pkg/apis/infrastructure/v1alpha3"
+	"github.com/giantswarm/micrologger/microloggertest"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+	"github.com/giantswarm/node-operator/service/controller/key"
+)
+
+func Test_clusterDeleted(t *testing.T) {
+	drainerConfig := v1alpha2.DrainerConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1", Namespace: "default"},
+		Spec: v1alpha2.DrainerConfigSpec{
+			WorkloadCluster: v1alpha2.DrainerConfigSpecWorkloadCluster{ID: "abc12"},
+		},
+	}
+
+	newCluster := func() *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(key.ClusterAPIGroupVersion.WithKind("Cluster"))
+		u.SetName("abc12")
+		u.SetNamespace("default")
+		return u
+	}
+
+	testCases := []struct {
+		name            string
+		awsClusterKnown bool
+		clusterKnown    bool
+		objects         []client.Object
+		expectedDeleted bool
+	}{
+		{
+			name:            "case 0: existing AWSCluster is not deleted",
+			awsClusterKnown: true,
+			clusterKnown:    true,
+			objects: []client.Object{
+				&infrastructurev1alpha3.AWSCluster{ObjectMeta: metav1.ObjectMeta{Name: "abc12", Namespace: "default"}},
+			},
+			expectedDeleted: false,
+		},
+		{
+			name:            "case 1: existing Cluster without AWSCluster is not deleted",
+			awsClusterKnown: true,
+			clusterKnown:    true,
+			objects:         []client.Object{newCluster()},
+			expectedDeleted: false,
+		},
+		{
+			name:            "case 2: existing Cluster without AWSCluster kind is not deleted",
+			clusterKnown:    true,
+			objects:         []client.Object{newCluster()},
+			expectedDeleted: false,
+		},
+		{
+			name:            "case 3: missing AWSCluster and Cluster are deleted",
+			awsClusterKnown: true,
+			clusterKnown:    true,
+			expectedDeleted: true,
+		},
+		{
+			name:            "case 4: missing Cluster without AWSCluster kind is deleted",
+			clusterKnown:    true,
+			expectedDeleted: true,
+		},
+		{
+			name:            "case 5: unknown kinds are not deleted",
+			expectedDeleted: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			err := infrastructurev1alpha3.AddToScheme(scheme)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Kinds whose CRDs are not installed in the management cluster
+			// cannot be mapped to resources.
+			get := func(ctx context.Context, c client.WithWatch, k client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				gvk, err := apiutil.GVKForObject(obj, scheme)
+				if err != nil {
+					return err
+				}
+				if (gvk.Kind == "AWSCluster" && !tc.awsClusterKnown) || (gvk.Kind == "Cluster" && !tc.clusterKnown) {
+					return &meta.NoKindMatchError{GroupKind: gvk.GroupKind(), SearchedVersions: []string{gvk.Version}}
+				}
+
+				return c.Get(ctx, k, obj, opts...)
+			}
+
+			r := &Resource{
+				client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.objects...).WithInterceptorFuncs(interceptor.Funcs{Get: get}).Build(),
+				logger: microloggertest.New(),
+			}
+
+			deleted, err := r.clusterDeleted(context.Background(), drainerConfig)
+			if err != nil {
+				t.Fatalf("error == %#v, want nil", err)
+			}
+
+			if deleted != tc.expectedDeleted {
+				t.Fatalf("deleted == %t, want %t", deleted, tc.expectedDeleted)
+			}
+		})
+	}
+}
+
+func Test_machineNode(t *testing.T) {
+	testCases := []struct {
+		name            string
+		machine         map[string]interface{}
+		expectedCluster string
+		expectedNode    string
+	}{
+		{
+			name: "case 0: machine with node",
+			machine: map[string]interface{}{
+				"spec":   map[string]interface{}{"clusterName": "abc12"},
+				"status": map[string]interface{}{"nodeRef": map[string]interface{}{"kind": "Node", "name": "ip-10-1-2-3"}},
+			},
+			expectedCluster: "abc12",
+			expectedNode:    "ip-10-1-2-3",
+		},
+		{
+			name: "case 1: machine without node",
+			machine: map[string]interface{}{
+				"spec": map[string]interface{}{"clusterName": "abc12"},
+			},
+			expectedCluster: "abc12",
+			expectedNode:    "",
+		},
+		{
+			name:            "case 2: empty machine",
+			machine:         map[string]interface{}{},
+			expectedCluster: "",
+			expectedNode:    "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster, node := machineNode(&unstructured.Unstructured{Object: tc.machine})
+
+			if cluster != tc.expectedCluster {
+				t.Fatalf("cluster == %#q, want %#q", cluster, tc.expectedCluster)
+			}
+			if node != tc.expectedNode {
+				t.Fatalf("node == %#q, want %#q", node, tc.expectedNode)
+			}
+		})
+	}
+}
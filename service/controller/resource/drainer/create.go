@@ -6,40 +6,89 @@ import (
 	"os"
 	"time"
 
-	infrastructurev1alpha3 "github.com/giantswarm/apiextensions/v6/pkg/apis/infrastructure/v1alpha3"
 	"github.com/giantswarm/errors/tenant"
-	"github.com/giantswarm/k8sclient/v7/pkg/k8sclient"
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/tenantcluster/v6/pkg/tenantcluster"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
+	pkgruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/retry"
 	"k8s.io/kubectl/pkg/drain"
 
-	v1alpha1 "github.com/giantswarm/node-operator/api"
+	"github.com/giantswarm/node-operator/api/v1alpha2"
 
 	"github.com/giantswarm/node-operator/service/controller/key"
+	"github.com/giantswarm/node-operator/service/credential"
+	"github.com/giantswarm/node-operator/service/executor"
+	event "github.com/giantswarm/node-operator/service/recorder"
 )
 
+// drainingHeartbeatInterval is the minimum amount of time between two updates
+// of the Draining condition while a drain is in flight.
+const drainingHeartbeatInterval = 30 * time.Second
+
+// maxConditionMessageLength is the maximum length of condition messages.
+const maxConditionMessageLength = 1024
+
 func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 	drainerConfig, err := key.ToDrainerConfig(obj)
 	if err != nil {
 		return microerror.Mask(err)
 	}
 
-	// Get AWSCluster Object to write events on it
-	awsCluster := &infrastructurev1alpha3.AWSCluster{}
-	err = r.client.Get(ctx, types.NamespacedName{Name: key.ClusterIDFromDrainerConfig(drainerConfig), Namespace: drainerConfig.Namespace}, awsCluster)
+	// Drainer configs referencing a Cluster API Machine drain its node
+	drainerConfig, err = r.resolveMachine(ctx, drainerConfig)
+	if IsMachineNotFound(err) {
+		// The node is gone together with its machine, so there is nothing
+		// left to drain
+		if drainerConfig.Status.HasDrainedCondition() {
+			r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
+			return nil
+		}
+
+		r.logger.LogCtx(ctx, "level", "warning", "message", fmt.Sprintf("could not resolve node of drainer config: %s", err))
+		return r.updateDrainerStatusFunc(ctx, drainerConfig, setDrainPhase(v1alpha2.DrainPhaseCompleted),
+			drainerConfig.Status.NewDrainedCondition(v1alpha2.ReasonNodeNotFound, fmt.Sprintf("Machine %s does not exist", key.MachineFromDrainerConfig(drainerConfig))),
+		)
+	} else if IsNodeRefMissing(err) {
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("waiting for node of drainer config: %s", err))
+		r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
+
+		return nil
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	// Get the object to write events on
+	eventTarget, err := r.eventTarget.Resolve(ctx, drainerConfig)
 	if err != nil {
 		return microerror.Mask(err)
 	}
 
+	// Events are recorded for the drainer config too, so that the drain
+	// history shows up when describing it
+	var eventTargets []event.Target
+	if _, ok := eventTarget.(*v1alpha2.DrainerConfig); !ok {
+		eventTargets = append(eventTargets, event.Target{Object: &drainerConfig})
+	}
+	ctx = event.WithTargets(ctx, eventTargets...)
+
 	// Get the node name we want to cordon and drain
 	nodeName := key.NodeNameFromDrainerConfig(drainerConfig)
 
+	// A retry of a failed drain got requested. A drain which is still known
+	// to the executor has its result processed first.
+	if _, ok := drainerConfig.Annotations[v1alpha2.RetryAnnotation]; ok {
+		state, _ := r.executor.Result(drainTaskID(drainerConfig))
+		if state == executor.StateUnknown {
+			return r.retryDrain(ctx, eventTarget, drainerConfig)
+		}
+	}
+
 	if drainerConfig.Status.HasDrainedCondition() {
 		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("%s drainer config status has drained condition", nodeName))
 		r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
@@ -54,33 +103,52 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 		return nil
 	}
 
+	if drainerConfig.Status.HasQuarantinedCondition() {
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("%s drainer config status has quarantined condition", nodeName))
+		r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
+
+		return nil
+	}
+
+	err = ValidateDrainPolicy(drainerConfig.Spec.Policy)
+	if IsInvalidDrainPolicy(err) {
+		r.logger.LogCtx(ctx, "level", "warning", "message", fmt.Sprintf("%s drainer config has an invalid drain policy: %s", nodeName, err))
+		r.event.Warn(ctx, eventTarget, "InvalidDrainPolicy", fmt.Sprintf("drainer config for node %s has an invalid drain policy: %s", nodeName, err))
+		r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
+
+		return nil
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if !drainerConfig.Spec.Cancel && !drainerConfig.Status.HasPendingCondition() && !drainerConfig.Status.HasDrainingCondition() {
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("set drainer config status of tenant cluster node %s to pending condition", nodeName))
+
+		err = r.updateDrainerStatus(ctx, drainerConfig,
+			drainerConfig.Status.NewPendingCondition(v1alpha2.ReasonDrainRequested, fmt.Sprintf("Waiting for node %s to be drained", nodeName)),
+		)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
 	// ====================================================================
 	// Setup the k8sclient
 
-	var restConfig *rest.Config
+	var k8sClient kubernetes.Interface
 	{
-		i := key.ClusterIDFromDrainerConfig(drainerConfig)
-		e := key.ClusterEndpointFromDrainerConfig(drainerConfig)
-		restConfig, err = r.tenantCluster.NewRestConfig(ctx, i, e)
+		k8sClient, err = r.clientCache.K8sClient(ctx, drainerConfig)
 		if tenantcluster.IsTimeout(err) {
 			r.logger.LogCtx(ctx, "level", "debug", "message", "fetching certificates timed out")
 			r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
 
 			return nil
-		} else if err != nil {
-			return microerror.Mask(err)
-		}
-	}
-
-	var k8sClient kubernetes.Interface
-	{
-		c := k8sclient.ClientsConfig{
-			Logger:     r.logger,
-			RestConfig: restConfig,
-		}
+		} else if credential.IsSecretNotFound(err) {
+			r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("credentials of workload cluster not found: %s", err))
+			r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
 
-		k8sClients, err := k8sclient.NewClients(c)
-		if tenant.IsAPINotAvailable(err) {
+			return nil
+		} else if tenant.IsAPINotAvailable(err) {
 			r.logger.LogCtx(ctx, "level", "debug", "message", "tenant cluster API is not available")
 			r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
 
@@ -88,8 +156,10 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 		} else if err != nil {
 			return microerror.Mask(err)
 		}
+	}
 
-		k8sClient = k8sClients.K8sClient()
+	if drainerConfig.Spec.Cancel {
+		return r.cancelDrain(ctx, eventTarget, k8sClient, drainerConfig)
 	}
 
 	// ====================================================================
@@ -111,33 +181,6 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 
 		// if we got here it means we have the list of nodes
 
-		// WARNING
-		// we are configuring here the draining behaviour for the worker nodes by default
-		// however we will modify it for the master node in the node loop right below
-		// WARNING
-		nodeShutdownHelper := drain.Helper{
-			Ctx:                             ctx,             // pass the current context
-			Client:                          k8sClient,       // the k8s client for making the API calls
-			Force:                           true,            // forcing the draining
-			GracePeriodSeconds:              60,              // 60 seconds of timeout before deleting the pod
-			IgnoreAllDaemonSets:             true,            // ignore the daemonsets
-			Timeout:                         5 * time.Minute, // give a 5 minutes timeout
-			DeleteEmptyDirData:              true,            // delete all the emptyDir volumes
-			DisableEviction:                 false,           // we want to evict and not delete. (might be different for the master nodes)
-			SkipWaitForDeleteTimeoutSeconds: 15,              // in case a node is NotReady then the pods won't be deleted, so don't wait too long
-			Out:                             os.Stdout,
-			ErrOut:                          os.Stderr,
-			OnPodDeletedOrEvicted: func(pod *v1.Pod, usingEviction bool) {
-				if pod != nil {
-					if usingEviction {
-						r.logger.LogCtx(ctx, "level", "info", "message", fmt.Sprintf("evicted pod %s", pod.GetName()))
-					} else {
-						r.logger.LogCtx(ctx, "level", "info", "message", fmt.Sprintf("deleted pod %s", pod.GetName()))
-					}
-				}
-			},
-		}
-
 		// Loop through the list of nodes
 		for _, node := range nodes.Items {
 
@@ -148,71 +191,242 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 				continue
 			}
 
+			// Events are recorded for the node in the workload cluster
+			// too
+			nodeTarget := event.Target{Object: &node, Recorder: r.event.WorkloadCluster(k8sClient)}
+			eventTargets = append(eventTargets, nodeTarget)
+			ctx = event.WithTargets(ctx, nodeTarget)
+
 			typeOfNode := "worker"
-			// In case of master nodes, adjust the timeouts and make them shorter
+			defaults := r.workerDrainPolicy
+			// In case of master nodes, the defaults usually have shorter timeouts
 			if nodeIsMaster(&node) {
-
-				// 45 seconds pods termination grace period
-				nodeShutdownHelper.GracePeriodSeconds = 45
-
-				// 1 minute max timout since we are blocking here
-				nodeShutdownHelper.Timeout = 2 * time.Minute
-
-				// Set type to master
 				typeOfNode = "master"
+				defaults = r.controlPlaneDrainPolicy
+			}
 
+			policy := newDrainPolicy(defaults, drainerConfig.Spec.Policy)
+
+			// Drains which failed too often delete pods instead of evicting
+			// them, so that PodDisruptionBudgets do not block them forever
+			policy, escalated := escalateDrainPolicy(policy, drainerConfig)
+
+			nodeShutdownHelper := drain.Helper{
+				Ctx:                             ctx,       // pass the current context
+				Client:                          k8sClient, // the k8s client for making the API calls
+				Force:                           policy.Force,
+				GracePeriodSeconds:              policy.GracePeriodSeconds,
+				IgnoreAllDaemonSets:             true, // ignore the daemonsets
+				Timeout:                         policy.Timeout,
+				DeleteEmptyDirData:              policy.DeleteEmptyDirData,
+				DisableEviction:                 policy.DisableEviction,
+				SkipWaitForDeleteTimeoutSeconds: policy.SkipWaitForDeleteTimeoutSeconds,
+				Out:                             os.Stdout,
+				ErrOut:                          os.Stderr,
+				OnPodDeletedOrEvicted: func(pod *v1.Pod, usingEviction bool) {
+					if pod != nil {
+						if usingEviction {
+							r.logger.LogCtx(ctx, "level", "info", "message", fmt.Sprintf("evicted pod %s", pod.GetName()))
+						} else {
+							r.logger.LogCtx(ctx, "level", "info", "message", fmt.Sprintf("deleted pod %s", pod.GetName()))
+						}
+					}
+				},
+			}
+
+			// Nodes which are only quarantined are not drained, so there
+			// is no need to do this in the background
+			action := key.ActionFromDrainerConfig(drainerConfig)
+			if action == v1alpha2.ActionCordon || action == v1alpha2.ActionTaint {
+				return r.quarantine(ctx, eventTarget, nodeShutdownHelper, node, typeOfNode, drainerConfig)
 			}
 
 			// Check if:
-			// - the node was already being drained
+			// - the node is already queued or being drained
 			// - we are done with the draining of the specific node
-			if draining, ok := r.draining[nodeName]; ok {
-				select {
-				case drainingError := <-draining:
+			id := drainTaskID(drainerConfig)
+			state, drainingError := r.executor.Result(id)
+
+			switch state {
+			case executor.StateDone:
+
+				// The cordon failed, which got reported in the status
+				// already, or the drain got canceled without being asked
+				// to, e.g. because the operator is shutting down, so try
+				// again
+				if IsCordonFailed(drainingError) || IsDrainCanceled(drainingError) {
+					r.executor.Forget(id)
+					return nil
+				}
 
-					// It means we successfully drained a node
-					if drainingError == nil {
-						// Remove the node from the state
-						r.removeNodeFromState(nodeName)
+				// It means we successfully drained a node
+				if drainingError == nil {
+					reason := v1alpha2.ReasonNodeDrained
+					message := fmt.Sprintf("Drained node %s", nodeName)
 
-						// update the node status to drained and return
-						return r.updateDrainerStatus(ctx, drainerConfig.Status.NewDrainedCondition(), drainerConfig, k8sClient)
-					}
+					// The node is not needed anymore once it got drained
+					if action == v1alpha2.ActionDrainAndDelete {
+						err := k8sClient.CoreV1().Nodes().Delete(ctx, nodeName, metav1.DeleteOptions{})
+						if err != nil && !apierrors.IsNotFound(err) {
+							return microerror.Mask(err)
+						}
 
-					// Otherwise we had an error, so set the condition to a timeout
-					err := r.updateDrainerStatus(ctx, drainerConfig.Status.NewTimeoutCondition(), drainerConfig, k8sClient)
+						r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("deleted drained %s node %s", typeOfNode, nodeName))
 
-					// If updating the status of the drainer config succeeded
-					// then we are done
-					if err == nil {
-						r.removeNodeFromState(nodeName)
-						return nil
+						reason = v1alpha2.ReasonNodeDeleted
+						message = fmt.Sprintf("Drained and deleted node %s", nodeName)
+					}
+
+					// update the node status to drained and return
+					err := r.updateDrainerStatusFunc(ctx, drainerConfig, setDrainPhase(v1alpha2.DrainPhaseCompleted),
+						falseCondition(drainerConfig.Status.NewDrainingCondition(reason, message)),
+						drainerConfig.Status.NewDrainedCondition(reason, message),
+					)
+					if err != nil {
+						return microerror.Mask(err)
 					}
 
-					// Otherwise try again to drain the node
-					draining <- drainingError
+					// Remove the drain from the executor only once its result
+					// is persisted, so that we try again otherwise
+					r.executor.Forget(id)
+					return nil
+				}
+
+				// Otherwise we had an error, so retry the drain after its
+				// backoff, or set the condition to a timeout if it failed
+				// too often already
+				reason := drainFailureReason(drainingError)
+				message := conditionMessage(fmt.Sprintf("Failed to drain node %s: %s", nodeName, drainingError))
 
-					// IMPORTANT!
-					// We need to do an eager return here, so that we don't fall back in the SPOT instance case
+				failures := 1
+				if drainerConfig.Status.Drain != nil {
+					failures = drainerConfig.Status.Drain.Failures + 1
+				}
+
+				if maxAttempts := key.MaxAttemptsFromDrainerConfig(drainerConfig); failures < maxAttempts {
+					backoff := retryBackoff(key.RetryBackoffFromDrainerConfig(drainerConfig), failures)
+					retryTime := metav1.NewTime(time.Now().Add(backoff))
+
+					r.logger.LogCtx(ctx, "level", "warning", "message", fmt.Sprintf("retrying drain of %s node %s in %s, attempt %d of %d", typeOfNode, nodeName, backoff, failures+1, maxAttempts))
+					r.event.Warn(ctx, eventTarget, "DrainingRetryScheduled", fmt.Sprintf("retrying drain of %s node %s in %s, attempt %d of %d", typeOfNode, nodeName, backoff, failures+1, maxAttempts))
+
+					err := r.updateDrainerStatusFunc(ctx, drainerConfig,
+						func(s *v1alpha2.DrainerConfigStatus) {
+							if s.Drain != nil {
+								s.Drain.Failures = failures
+								s.Drain.Phase = v1alpha2.DrainPhaseFailed
+								s.Drain.RetryTime = &retryTime
+							}
+						},
+						falseCondition(drainerConfig.Status.NewDrainingCondition(reason, message)),
+						drainerConfig.Status.NewFailedCondition(reason, message),
+						drainerConfig.Status.NewPendingCondition(v1alpha2.ReasonRetryScheduled, fmt.Sprintf("Retrying drain of node %s at %s, attempt %d of %d", nodeName, retryTime.UTC().Format(time.RFC3339), failures+1, maxAttempts)),
+					)
+					if err != nil {
+						return microerror.Mask(err)
+					}
+
+					r.executor.Forget(id)
 					return nil
+				}
+
+				err := r.updateDrainerStatusFunc(ctx, drainerConfig,
+					func(s *v1alpha2.DrainerConfigStatus) {
+						if s.Drain != nil {
+							s.Drain.Failures = failures
+							s.Drain.Phase = v1alpha2.DrainPhaseFailed
+							s.Drain.RetryTime = nil
+						}
+					},
+					falseCondition(drainerConfig.Status.NewDrainingCondition(reason, message)),
+					drainerConfig.Status.NewFailedCondition(reason, message),
+					drainerConfig.Status.NewTimeoutCondition(reason, message),
+				)
+				if err != nil {
+					return microerror.Mask(err)
+				}
+
+				r.executor.Forget(id)
+				return nil
+
+			case executor.StateQueued, executor.StateRunning:
+
+				// The drain is still in flight, so let the status show
+				// that we are still working on it. We get reconciled
+				// again as soon as the drain finishes.
+				if drainingHeartbeatDue(drainerConfig.Status) {
+					err := r.updateDrainerStatus(ctx, drainerConfig)
+					if err != nil {
+						return microerror.Mask(err)
+					}
+				}
+
+				// IMPORTANT!
+				// We need to do an eager return here, so that we don't fall back in the SPOT instance case
+				return nil
+
+			default:
+
+				// A failed drain waits for its backoff before it gets
+				// retried. We get reconciled again with the next resync.
+				if d := drainerConfig.Status.Drain; d != nil && d.RetryTime != nil && time.Now().Before(d.RetryTime.Time) {
+					r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("waiting until %s to retry drain of %s node %s", d.RetryTime.UTC().Format(time.RFC3339), typeOfNode, nodeName))
+					r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
 
-					// -------------------------------------------------------------------
-					// We need to pick a number here.
-					// Unfortunately there is no right amount of time to wait for the
-					// operation to complete. In various tests it seems a value
-					// between 10 and 5 is performing well. So picking the average and floring it
-				case <-time.After(7 * time.Second):
-					// we want to wait only for a max of N seconds, otherwise continue
-					// IMPORTANT!
-					// We need to do an eager return here, so that we don't fall back in the SPOT instance case
 					return nil
 				}
 
-			} else {
+				if escalated {
+					r.logger.LogCtx(ctx, "level", "warning", "message", fmt.Sprintf("deleting pods instead of evicting them from %s node %s after %d failed attempts", typeOfNode, nodeName, drainerConfig.Status.Drain.Failures))
+					r.event.Warn(ctx, eventTarget, "DrainingEscalated", fmt.Sprintf("deleting pods instead of evicting them from %s node %s after %d failed attempts", typeOfNode, nodeName, drainerConfig.Status.Drain.Failures))
+				}
+
+				// Drains which are resumed went through the preflight
+				// analysis already
+				if !drainerConfig.Status.IsDrainInFlight() {
+					start, err := r.preflight(ctx, eventTarget, k8sClient, node, typeOfNode, policy, drainerConfig)
+					if tenant.IsAPINotAvailable(err) {
+						r.logger.LogCtx(ctx, "level", "debug", "message", "tenant cluster API is not available")
+						r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
+
+						return nil
+					} else if err != nil {
+						return microerror.Mask(err)
+					}
+
+					// We get reconciled again with the next resync and
+					// check whether the blockers cleared
+					if !start {
+						r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
+						return nil
+					}
+				}
+
+				t := executor.Task{
+					Cluster: key.ClusterIDFromDrainerConfig(drainerConfig),
+					ID:      id,
+					Run: func(ctx context.Context) error {
+						ctx = event.WithTargets(ctx, eventTargets...)
+						return r.drainNodeAsync(nodeName, typeOfNode, ctx, eventTarget, policy, nodeShutdownHelper, node, k8sClient, drainerConfig)
+					},
+					OnDone: func(ctx context.Context, err error) {
+						r.requeue(ctx, drainerConfig)
+					},
+				}
+
+				// Queue the drain. The executor limits how many drains run
+				// at the same time, overall and per workload cluster.
+				err := r.executor.Submit(ctx, t)
+				if executor.IsQueueFull(err) {
+					r.logger.LogCtx(ctx, "level", "warning", "message", fmt.Sprintf("cannot queue drain of %s node %s: %s", typeOfNode, nodeName, err))
+					r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
+
+					return nil
+				} else if err != nil {
+					return microerror.Mask(err)
+				}
 
-				// drain async and add the status to the state
-				// Important to run in a different go routine
-				go r.drainNodeAsync(nodeName, typeOfNode, ctx, *awsCluster, nodeShutdownHelper, node, k8sClient, drainerConfig)
+				r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("queued drain of %s node %s", typeOfNode, nodeName))
 
 				// IMPORTANT!
 				// We need to do an eager return here, so that we don't fall back in the SPOT instance case
@@ -226,114 +440,334 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 		// if we get here it means we could not find the instance in the list of nodes
 		// this can happen for example if an instance is SPOT and therefore AWS just deletes it
 		r.logger.LogCtx(ctx, "level", "warn", "message", "Could not find the instance. Setting the draining status to: drained")
-		return r.updateDrainerStatus(ctx, drainerConfig.Status.NewDrainedCondition(), drainerConfig, k8sClient)
+		return r.updateDrainerStatusFunc(ctx, drainerConfig, setDrainPhase(v1alpha2.DrainPhaseCompleted),
+			drainerConfig.Status.NewDrainedCondition(v1alpha2.ReasonNodeNotFound, fmt.Sprintf("Node %s does not exist in the workload cluster", nodeName)),
+		)
 
 	}
 }
 
-// Removes the node from the shared state
-func (r *Resource) removeNodeFromState(nodeName string) {
-	r.lock.Lock()
-	delete(r.draining, nodeName)
-	r.lock.Unlock()
+// Update the drainer config status. The status of a drain in flight gets its
+// heartbeat updated with every update.
+func (r *Resource) updateDrainerStatus(ctx context.Context,
+	drainerConfig v1alpha2.DrainerConfig,
+	conditions ...metav1.Condition) error {
+
+	return r.updateDrainerStatusFunc(ctx, drainerConfig, nil, conditions...)
 }
 
-// Update the drainer config status
-func (r *Resource) updateDrainerStatus(ctx context.Context,
-	status v1alpha1.DrainerConfigStatusCondition,
-	drainerConfig v1alpha1.DrainerConfig, k8sClient kubernetes.Interface) error {
+// Update the drainer config status like updateDrainerStatus, additionally
+// applying the given mutation to the latest status, if any.
+func (r *Resource) updateDrainerStatusFunc(ctx context.Context,
+	drainerConfig v1alpha2.DrainerConfig,
+	mutate func(*v1alpha2.DrainerConfigStatus),
+	conditions ...metav1.Condition) error {
+
+	// The status is updated by both the reconciliation loop and the draining
+	// goroutine, so the conditions are always applied to the latest version
+	// of the CR
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &v1alpha2.DrainerConfig{}
+		err := r.client.Get(ctx, types.NamespacedName{Name: drainerConfig.Name, Namespace: drainerConfig.Namespace}, latest)
+		if err != nil {
+			return err
+		}
 
-	// Set the status
-	drainerConfig.Status.Conditions = append(drainerConfig.Status.Conditions, status)
+		// Set the status
+		if mutate != nil {
+			mutate(&latest.Status)
+		}
+		for _, c := range conditions {
+			c.ObservedGeneration = latest.Generation
+			latest.Status.SetCondition(c)
+		}
+		latest.Status.ObservedGeneration = latest.Generation
 
-	// Update the CR
-	return r.client.Status().Update(ctx, &drainerConfig)
+		if latest.Status.HasDrainingCondition() {
+			now := metav1.Now()
+			latest.Status.LastHeartbeatTime = &now
+		}
 
+		// Update the CR
+		return r.client.Status().Update(ctx, latest)
+	})
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
 }
 
 // Cordons a node in a blocking way
 func (r *Resource) cordon(ctx context.Context,
-	awsCluster infrastructurev1alpha3.AWSCluster,
+	eventTarget pkgruntime.Object,
 	shutdownHelper drain.Helper,
-	node v1.Node, typeOfNode string) error {
+	node v1.Node, typeOfNode string,
+	drainerConfig v1alpha2.DrainerConfig) error {
 
 	// Signal that we started cordoning the node
 	r.logger.LogCtx(ctx, "level", "info", "message", "cordoning tenant cluster node")
 
-	// Cordon the node
-	if err := drain.RunCordonOrUncordon(&shutdownHelper, &node, true); err != nil {
+	// Cordon and taint the node
+	err := drain.RunCordonOrUncordon(&shutdownHelper, &node, true)
+	if err == nil {
+		err = r.taintNode(ctx, shutdownHelper.Client, node.GetName(), key.TaintsFromDrainerConfig(drainerConfig))
+	}
+	if err != nil {
 		r.logger.LogCtx(ctx, "level", "error", "message", fmt.Sprintf("failed to cordon %s node with error %s", typeOfNode, err))
-		r.event.Warn(ctx, &awsCluster, "CordoningFailed", fmt.Sprintf("failed to cordon %s node %s with error %s", typeOfNode, node.GetName(), err))
+		r.event.Warn(ctx, eventTarget, "CordoningFailed", fmt.Sprintf("failed to cordon %s node %s with error %s", typeOfNode, node.GetName(), err))
+
+		message := conditionMessage(fmt.Sprintf("Failed to cordon node %s: %s", node.GetName(), err))
+		if err := r.updateDrainerStatus(ctx, drainerConfig, drainerConfig.Status.NewFailedCondition(v1alpha2.ReasonCordonFailed, message)); err != nil {
+			r.logger.LogCtx(ctx, "level", "error", "message", fmt.Sprintf("failed to set drainer config status to failed condition with error %s", err))
+		}
+
 		return err
 	}
 
 	// Log the node as cordoned
 	r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("cordoned %s node", typeOfNode))
-	return nil
+
+	// A previous attempt to cordon the node may have failed
+	message := fmt.Sprintf("Cordoned node %s", node.GetName())
+	conditions := []metav1.Condition{
+		drainerConfig.Status.NewCordonedCondition(v1alpha2.ReasonNodeCordoned, message),
+	}
+	if drainerConfig.Status.HasFailedCondition() {
+		conditions = append(conditions, falseCondition(drainerConfig.Status.NewFailedCondition(v1alpha2.ReasonNodeCordoned, message)))
+	}
+
+	return r.updateDrainerStatus(ctx, drainerConfig, conditions...)
+}
+
+// Cordons and taints a node without draining it
+func (r *Resource) quarantine(ctx context.Context,
+	eventTarget pkgruntime.Object,
+	shutdownHelper drain.Helper,
+	node v1.Node, typeOfNode string,
+	drainerConfig v1alpha2.DrainerConfig) error {
+
+	// A failure got reported in the status already, which gets us reconciled
+	// again
+	if err := r.cordon(ctx, eventTarget, shutdownHelper, node, typeOfNode, drainerConfig); err != nil {
+		return nil
+	}
+
+	r.event.Info(ctx, eventTarget, "NodeQuarantined", fmt.Sprintf("quarantined %s node %s", typeOfNode, node.GetName()))
+
+	message := fmt.Sprintf("Quarantined node %s", node.GetName())
+	return r.updateDrainerStatus(ctx, drainerConfig,
+		falseCondition(drainerConfig.Status.NewPendingCondition(v1alpha2.ReasonNodeQuarantined, message)),
+		drainerConfig.Status.NewQuarantinedCondition(v1alpha2.ReasonNodeQuarantined, message),
+	)
 }
 
 // Shared method for draining a node
 func (r *Resource) drainNode(nodeName string,
 	typeOfNode string,
 	ctx context.Context,
-	awsCluster infrastructurev1alpha3.AWSCluster,
+	eventTarget pkgruntime.Object,
 	shutdownHelper drain.Helper,
 	node v1.Node, k8sClient kubernetes.Interface,
-	drainerConfig v1alpha1.DrainerConfig) error {
+	drainerConfig v1alpha2.DrainerConfig) error {
 
 	// The draining function is going to block until the draining is successful
 	// or a timeout happens (whichever happens first)
-	if err := drain.RunNodeDrain(&shutdownHelper, nodeName); err != nil {
+	if err := r.runNodeDrain(ctx, eventTarget, shutdownHelper, node, typeOfNode, drainerConfig); err != nil {
+
+		// The drain got canceled, so there is nothing to report
+		if ctx.Err() != nil {
+			r.logger.LogCtx(ctx, "level", "info", "message", fmt.Sprintf("stopped draining %s node", typeOfNode))
+			return microerror.Maskf(drainCanceledError, "%s", err)
+		}
 
 		// This means the draining failed
 		// Log it
 		r.logger.LogCtx(ctx, "level", "error", "message", fmt.Sprintf("failed to drain %s node with error %s", typeOfNode, err))
-		r.event.Warn(ctx, &awsCluster, "DrainingFailed", fmt.Sprintf("failed to drain %s node %s with error %s", typeOfNode, node.GetName(), err))
+		r.event.Warn(ctx, eventTarget, "DrainingFailed", fmt.Sprintf("failed to drain %s node %s with error %s", typeOfNode, node.GetName(), err))
 
-		// log all the pods that could not be evicted or deleted
-		r.logUnevictedPods(k8sClient, ctx, &awsCluster, typeOfNode, &node)
+		// Record the pods that could not be evicted or deleted, so that
+		// whoever requested the drain can act on exactly those pods
+		unevicted := r.recordUnevictedPods(k8sClient, ctx, eventTarget, typeOfNode, &node, drainerConfig)
 
-		// Return the error
-		return microerror.Mask(err)
+		// Return the error, telling apart why the drain failed
+		return classifyDrainError(err, unevicted)
 
 	}
 
 	// Emit the events that the draining was successful
 	r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("set drainer config status of tenant cluster %s node to drained condition", typeOfNode))
-	r.event.Info(ctx, &awsCluster, "DrainingSucceeded", fmt.Sprintf("drained %s node %s successfully", typeOfNode, node.GetName()))
+	r.event.Info(ctx, eventTarget, "DrainingSucceeded", fmt.Sprintf("drained %s node %s successfully", typeOfNode, node.GetName()))
 
 	return nil
 
 }
 
-// Drain a node
+// Drain a node. It is run by the drain executor.
 func (r *Resource) drainNodeAsync(
 	nodeName string,
 	typeOfNode string,
 	ctx context.Context,
-	awsCluster infrastructurev1alpha3.AWSCluster,
+	eventTarget pkgruntime.Object,
+	policy DrainPolicy,
 	shutdownHelper drain.Helper,
 	node v1.Node, k8sClient kubernetes.Interface,
-	drainerConfig v1alpha1.DrainerConfig) {
+	drainerConfig v1alpha2.DrainerConfig) error {
+
+	// The drain is persisted in the status, so that a drain which was in
+	// flight when the operator restarted gets resumed instead of being
+	// started over or forgotten
+	now := time.Now()
+	drainState := nextDrain(drainerConfig.Status.Drain, now)
+
+	timeout, expired := remainingDrainTimeout(policy.Timeout, drainState, now)
+	if expired {
+		r.logger.LogCtx(ctx, "level", "warning", "message", fmt.Sprintf("drain of %s node %s started at %s timed out while it was interrupted", typeOfNode, nodeName, drainState.StartTime))
+		r.event.Warn(ctx, eventTarget, "DrainingFailed", fmt.Sprintf("drain of %s node %s timed out while it was interrupted", typeOfNode, nodeName))
+
+		return microerror.Maskf(drainTimeoutError, "drain did not finish within %s", policy.Timeout)
+	}
+
+	if drainerConfig.Status.IsDrainInFlight() {
+		r.logger.LogCtx(ctx, "level", "info", "message", fmt.Sprintf("resuming interrupted drain of %s node %s, attempt %d", typeOfNode, nodeName, drainState.Attempt))
+	}
+
+	err := r.updateDrainerStatusFunc(ctx, drainerConfig, func(s *v1alpha2.DrainerConfigStatus) {
+		s.Drain = drainState
+		s.UnevictedPods = nil
+	})
+	if err != nil {
+		r.logger.LogCtx(ctx, "level", "error", "message", fmt.Sprintf("failed to persist drain state with error %s", err))
+	}
+
+	// The drain gets canceled together with the executor task
+	shutdownHelper.Ctx = ctx
+	shutdownHelper.Timeout = timeout
 
 	// Cordon the node
-	if err := r.cordon(ctx, awsCluster, shutdownHelper, node, typeOfNode); err != nil {
-		// return in case of failure so that we can retry
-		return
+	if err := r.cordon(ctx, eventTarget, shutdownHelper, node, typeOfNode, drainerConfig); err != nil {
+		return microerror.Maskf(cordonFailedError, "%s", err)
+	}
+
+	// Count the pods removed from the node, so that the status tells how far
+	// the drain got and whether it stalls. Pods which cannot be evicted make
+	// the drain fail right away, so there is nothing to count then.
+	var total int
+	if list, errs := shutdownHelper.GetPodsForDeletion(nodeName); len(errs) == 0 && list != nil {
+		total = len(list.Pods())
+	}
+	progress := newDrainProgress(total, time.Now())
+	{
+		onPodDeletedOrEvicted := shutdownHelper.OnPodDeletedOrEvicted
+		shutdownHelper.OnPodDeletedOrEvicted = func(pod *v1.Pod, usingEviction bool) {
+			if onPodDeletedOrEvicted != nil {
+				onPodDeletedOrEvicted(pod, usingEviction)
+			}
+			progress.podRemoved(usingEviction, time.Now())
+		}
+	}
+
+	// Signal that the pods are about to be evicted
+	message := fmt.Sprintf("Evicting %d pods from node %s", total, nodeName)
+	err = r.updateDrainerStatusFunc(ctx, drainerConfig,
+		func(s *v1alpha2.DrainerConfigStatus) {
+			setDrainPhase(v1alpha2.DrainPhaseEvicting)(s)
+			if s.Drain != nil {
+				p := progress.snapshot()
+				s.Drain.Progress = &p
+			}
+		},
+		falseCondition(drainerConfig.Status.NewPendingCondition(v1alpha2.ReasonDrainStarted, message)),
+		drainerConfig.Status.NewDrainingCondition(v1alpha2.ReasonDrainStarted, message),
+	)
+	if err != nil {
+		r.logger.LogCtx(ctx, "level", "error", "message", fmt.Sprintf("failed to set drainer config status to draining condition with error %s", err))
+	}
+
+	// Drain the node now, recording its progress along the way. The progress
+	// is recorded on the next tick if the status update above failed.
+	stopReporting := r.reportDrainProgress(ctx, drainerConfig, progress, err == nil)
+	err = r.drainNode(nodeName, typeOfNode, ctx, eventTarget, shutdownHelper, node, k8sClient, drainerConfig)
+	stopReporting()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	// Wait for the volumes of the node to be detached, if requested, so that
+	// the node is only reported as drained once stateful workloads can
+	// attach their volumes elsewhere
+	if drainerConfig.Spec.VolumeDetach != nil {
+		err = r.waitForVolumeDetach(ctx, eventTarget, k8sClient, node, typeOfNode, drainerConfig)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	return nil
+}
+
+// Triggers another reconciliation of the drainer config by updating its
+// heartbeat, e.g. once its drain finished
+func (r *Resource) requeue(ctx context.Context, drainerConfig v1alpha2.DrainerConfig) {
+	err := r.updateDrainerStatusFunc(ctx, drainerConfig, func(s *v1alpha2.DrainerConfigStatus) {
+		now := metav1.Now()
+		s.LastHeartbeatTime = &now
+	})
+	// The drainer config may have been deleted in the meantime
+	if err != nil && !apierrors.IsNotFound(err) {
+		r.logger.LogCtx(ctx, "level", "error", "message", fmt.Sprintf("failed to requeue drainer config with error %s", err))
+	}
+}
+
+// Returns the ID of the drain of the given drainer config in the executor
+func drainTaskID(drainerConfig v1alpha2.DrainerConfig) string {
+	return types.NamespacedName{Name: drainerConfig.Name, Namespace: drainerConfig.Namespace}.String()
+}
+
+// Returns a copy of the given condition with its status set to False
+func falseCondition(c metav1.Condition) metav1.Condition {
+	c.Status = metav1.ConditionFalse
+	return c
+}
+
+// Checks whether the heartbeat of a drain in flight should be updated. We do
+// not want to update the status on every reconciliation, as every update
+// triggers another one.
+func drainingHeartbeatDue(status v1alpha2.DrainerConfigStatus) bool {
+	if !status.HasDrainingCondition() {
+		return false
 	}
+	if status.LastHeartbeatTime == nil {
+		return true
+	}
+
+	return time.Since(status.LastHeartbeatTime.Time) > drainingHeartbeatInterval
+}
 
-	// Await channel
-	// Create a channel with a buffer, so that we don't block
-	await := make(chan error, 2)
+// Returns the reason of the Failed condition for the given drain error
+func drainFailureReason(err error) string {
+	switch {
+	case IsPodDisruptionBudgetBlocked(err):
+		return v1alpha2.ReasonPodDisruptionBudgetBlocked
+	case tenant.IsAPINotAvailable(err):
+		return v1alpha2.ReasonAPIUnavailable
+	case IsDrainTimeout(err):
+		return v1alpha2.ReasonTimeout
+	case IsVolumeDetachTimeout(err):
+		return v1alpha2.ReasonVolumeDetachTimeout
+	default:
+		return v1alpha2.ReasonDrainFailed
+	}
+}
 
-	// Add the node to the shared state because it's about to be drained
-	r.lock.Lock()
-	r.draining[nodeName] = await
-	r.lock.Unlock()
+// Truncates condition messages, so that long error messages do not blow up
+// the status
+func conditionMessage(message string) string {
+	if len(message) > maxConditionMessageLength {
+		return message[:maxConditionMessageLength-3] + "..."
+	}
 
-	// Drain the node now
-	err := r.drainNode(nodeName, typeOfNode, ctx, awsCluster, shutdownHelper, node, k8sClient, drainerConfig)
-	await <- microerror.Mask(err)
+	return message
 }
 
 // Checks whether a node is a master node
@@ -366,25 +800,6 @@ func nodeIsMaster(node *v1.Node) bool {
 
 }
 
-func (r *Resource) logUnevictedPods(k8sClient kubernetes.Interface, ctx context.Context, awsCluster *infrastructurev1alpha3.AWSCluster, typeOfNode string, node *v1.Node) {
-	// Get the list of pods for the specific node
-	nodePods, err := nodePods(k8sClient, ctx, node)
-
-	// Log all the pods that could not be drained/deleted
-	if err == nil {
-		for _, pod := range nodePods {
-			r.logger.LogCtx(ctx, "level", "info", "message", fmt.Sprintf("could not evict/delete pod %s on %s node", pod.GetName(), typeOfNode))
-			r.event.Warn(ctx, awsCluster, "DrainerConfigFailed", fmt.Sprintf("%s node %s could not evict/delete pod %s", typeOfNode, node.GetName(), pod.GetName()))
-		}
-	}
-
-	// if instead we got an error log it
-	if err != nil {
-		r.logger.LogCtx(ctx, "level", "error", "message", fmt.Sprintf("could not get the list of pods: %s", err))
-		r.event.Warn(ctx, awsCluster, "DrainerConfigFailed", fmt.Sprintf("could not get the list of pods for the node %s: %s", node.GetName(), err))
-	}
-}
-
 // Returns the list of pods for the node
 func nodePods(k8sClient kubernetes.Interface, ctx context.Context, node *v1.Node) ([]v1.Pod, error) {
 	fieldSelector := fields.SelectorFromSet(fields.Set{
@@ -2,75 +2,116 @@ package drainer
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/giantswarm/errors/tenant"
-	"github.com/giantswarm/k8sclient/v7/pkg/k8sclient"
 	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/operatorkit/v7/pkg/controller/context/finalizerskeptcontext"
 	"github.com/giantswarm/tenantcluster/v6/pkg/tenantcluster"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/rest"
 
+	"github.com/giantswarm/node-operator/api/v1alpha2"
 	"github.com/giantswarm/node-operator/service/controller/key"
+	"github.com/giantswarm/node-operator/service/credential"
+	"github.com/giantswarm/node-operator/service/executor"
 )
 
+// EnsureDeleted applies the spec.onDelete action to the node. The finalizer
+// of the DrainerConfig is kept until the action is done, unless the workload
+// cluster is gone.
 func (r *Resource) EnsureDeleted(ctx context.Context, obj interface{}) error {
 	drainerConfig, err := key.ToDrainerConfig(obj)
 	if err != nil {
 		return microerror.Mask(err)
 	}
 
-	var restConfig *rest.Config
+	nodeName := key.NodeNameFromDrainerConfig(drainerConfig)
+
+	// A drain in flight gets canceled before anything else happens to the
+	// node. The drain in the executor stops asynchronously, so we keep the
+	// finalizer until it did. We get reconciled again once it stopped.
+	var canceled bool
 	{
-		i := key.ClusterIDFromDrainerConfig(drainerConfig)
-		e := key.ClusterEndpointFromDrainerConfig(drainerConfig)
-		restConfig, err = r.tenantCluster.NewRestConfig(ctx, i, e)
-		if tenantcluster.IsTimeout(err) {
-			r.logger.LogCtx(ctx, "level", "debug", "message", "fetching certificates timed out")
-			r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
+		state, _ := r.executor.Result(drainTaskID(drainerConfig))
+		canceled = state == executor.StateQueued || state == executor.StateRunning ||
+			drainerConfig.Status.IsDrainInFlight() || drainerConfig.Status.IsDrainCanceled()
+
+		if !r.stopDrain(drainerConfig) {
+			r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("waiting for drain of node %s to stop", nodeName))
+			r.logger.LogCtx(ctx, "level", "debug", "message", "keeping finalizers")
+			finalizerskeptcontext.SetKept(ctx)
 
 			return nil
-		} else if err != nil {
-			return microerror.Mask(err)
 		}
 	}
 
+	// The node is wanted back when its drain got aborted
+	onDelete := key.OnDeleteFromDrainerConfig(drainerConfig)
+	if canceled && drainerConfig.Spec.UncordonOnCancel {
+		onDelete = v1alpha2.OnDeleteUncordonNode
+	}
+
+	if onDelete == v1alpha2.OnDeleteLeaveCordoned {
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("leaving tenant cluster node %s as it is", nodeName))
+		r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
+
+		return nil
+	}
+
+	// Drainer configs referencing a Cluster API Machine act on its node. A
+	// machine without node has no node to act on.
+	drainerConfig, err = r.resolveMachine(ctx, drainerConfig)
+	if IsMachineNotFound(err) || IsNodeRefMissing(err) {
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("no tenant cluster node to act on: %s", err))
+		r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
+
+		return nil
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+	nodeName = key.NodeNameFromDrainerConfig(drainerConfig)
+
 	var k8sClient kubernetes.Interface
 	{
-		c := k8sclient.ClientsConfig{
-			Logger:     r.logger,
-			RestConfig: restConfig,
+		k8sClient, err = r.clientCache.K8sClient(ctx, drainerConfig)
+		if tenantcluster.IsTimeout(err) {
+			r.logger.LogCtx(ctx, "level", "debug", "message", "fetching certificates timed out")
+			return r.keepFinalizersUnlessClusterDeleted(ctx, drainerConfig)
+		} else if credential.IsSecretNotFound(err) {
+			r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("credentials of workload cluster not found: %s", err))
+			return r.keepFinalizersUnlessClusterDeleted(ctx, drainerConfig)
+		} else if tenant.IsAPINotAvailable(err) {
+			r.logger.LogCtx(ctx, "level", "debug", "message", "tenant cluster API is not available")
+			return r.keepFinalizersUnlessClusterDeleted(ctx, drainerConfig)
+		} else if err != nil {
+			return microerror.Mask(err)
 		}
+	}
+
+	if onDelete == v1alpha2.OnDeleteUncordonNode {
+		r.logger.LogCtx(ctx, "level", "debug", "message", "uncordoning tenant cluster node")
 
-		k8sClients, err := k8sclient.NewClients(c)
+		err := r.uncordon(ctx, k8sClient, nodeName, key.TaintsFromDrainerConfig(drainerConfig))
 		if tenant.IsAPINotAvailable(err) {
 			r.logger.LogCtx(ctx, "level", "debug", "message", "tenant cluster API is not available")
-			r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
-
-			return nil
+			return r.keepFinalizersUnlessClusterDeleted(ctx, drainerConfig)
 		} else if err != nil {
 			return microerror.Mask(err)
 		}
 
-		k8sClient = k8sClients.K8sClient()
+		return nil
 	}
 
 	{
 		r.logger.LogCtx(ctx, "level", "debug", "message", "deleting tenant cluster node from Kubernetes API")
 
-		nodeName := key.NodeNameFromDrainerConfig(drainerConfig)
-
-		// make sure the entry in the state is removed
-		r.removeNodeFromState(nodeName)
-
 		err := k8sClient.CoreV1().Nodes().Delete(ctx, nodeName, metav1.DeleteOptions{})
 		if tenant.IsAPINotAvailable(err) {
 			r.logger.LogCtx(ctx, "level", "debug", "message", "did not delete tenant cluster node from Kubernetes API")
 			r.logger.LogCtx(ctx, "level", "debug", "message", "tenant cluster API is not available")
-			r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
-
-			return nil
+			return r.keepFinalizersUnlessClusterDeleted(ctx, drainerConfig)
 		} else if apierrors.IsNotFound(err) {
 			r.logger.LogCtx(ctx, "level", "debug", "message", "did not delete tenant cluster node from Kubernetes API")
 			r.logger.LogCtx(ctx, "level", "debug", "message", "tenant cluster node not found")
@@ -86,3 +127,25 @@ func (r *Resource) EnsureDeleted(ctx context.Context, obj interface{}) error {
 
 	return nil
 }
+
+// Keeps the finalizers of the drainer config, so that the delete action is
+// tried again, unless the workload cluster got deleted, in which case there
+// is no node left to act on.
+func (r *Resource) keepFinalizersUnlessClusterDeleted(ctx context.Context, drainerConfig v1alpha2.DrainerConfig) error {
+	deleted, err := r.clusterDeleted(ctx, drainerConfig)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if deleted {
+		r.logger.LogCtx(ctx, "level", "debug", "message", "workload cluster got deleted")
+		r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
+
+		return nil
+	}
+
+	r.logger.LogCtx(ctx, "level", "debug", "message", "keeping finalizers")
+	finalizerskeptcontext.SetKept(ctx)
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package drainer
+
+import "github.com/giantswarm/microerror"
+
+var invalidConfigError = &microerror.Error{
+	Kind: "invalidConfigError",
+}
+
+// IsInvalidConfig asserts invalidConfigError.
+func IsInvalidConfig(err error) bool {
+	return microerror.Cause(err) == invalidConfigError
+}
+
+var invalidDrainPolicyError = &microerror.Error{
+	Kind: "invalidDrainPolicyError",
+}
+
+// IsInvalidDrainPolicy asserts invalidDrainPolicyError.
+func IsInvalidDrainPolicy(err error) bool {
+	return microerror.Cause(err) == invalidDrainPolicyError
+}
+
+var cordonFailedError = &microerror.Error{
+	Kind: "cordonFailedError",
+}
+
+// IsCordonFailed asserts cordonFailedError.
+func IsCordonFailed(err error) bool {
+	return microerror.Cause(err) == cordonFailedError
+}
+
+var drainCanceledError = &microerror.Error{
+	Kind: "drainCanceledError",
+}
+
+// IsDrainCanceled asserts drainCanceledError.
+func IsDrainCanceled(err error) bool {
+	return microerror.Cause(err) == drainCanceledError
+}
+
+var drainTimeoutError = &microerror.Error{
+	Kind: "drainTimeoutError",
+}
+
+// IsDrainTimeout asserts drainTimeoutError.
+func IsDrainTimeout(err error) bool {
+	return microerror.Cause(err) == drainTimeoutError
+}
+
+var podDisruptionBudgetBlockedError = &microerror.Error{
+	Kind: "podDisruptionBudgetBlockedError",
+}
+
+// IsPodDisruptionBudgetBlocked asserts podDisruptionBudgetBlockedError.
+func IsPodDisruptionBudgetBlocked(err error) bool {
+	return microerror.Cause(err) == podDisruptionBudgetBlockedError
+}
+
+var volumeDetachTimeoutError = &microerror.Error{
+	Kind: "volumeDetachTimeoutError",
+}
+
+// IsVolumeDetachTimeout asserts volumeDetachTimeoutError.
+func IsVolumeDetachTimeout(err error) bool {
+	return microerror.Cause(err) == volumeDetachTimeoutError
+}
+
+var machineNotFoundError = &microerror.Error{
+	Kind: "machineNotFoundError",
+}
+
+// IsMachineNotFound asserts machineNotFoundError.
+func IsMachineNotFound(err error) bool {
+	return microerror.Cause(err) == machineNotFoundError
+}
+
+var nodeRefMissingError = &microerror.Error{
+	Kind: "nodeRefMissingError",
+}
+
+// IsNodeRefMissing asserts nodeRefMissingError.
+func IsNodeRefMissing(err error) bool {
+	return microerror.Cause(err) == nodeRefMissingError
+}
@@ -0,0 +1,236 @@
+package drainer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/kubectl/pkg/drain"
+
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+)
+
+// forceDeleteInterval is the amount of time between two attempts to force
+// delete the pods stuck terminating on a node.
+const forceDeleteInterval = 10 * time.Second
+
+// Runs the drain of the node, escalating as configured in spec.escalation.
+// Pods left after evicting them for DeleteAfter are deleted instead, bypassing
+// PodDisruptionBudgets, and pods stuck terminating after ForceDeleteAfter are
+// deleted with a grace period of zero. Pods of unreachable nodes are deleted
+// with a grace period of zero right away if ForceDeleteOnUnreachableNode is
+// set, and their VolumeAttachments once the node got drained.
+func (r *Resource) runNodeDrain(ctx context.Context,
+	eventTarget pkgruntime.Object,
+	shutdownHelper drain.Helper,
+	node v1.Node, typeOfNode string,
+	drainerConfig v1alpha2.DrainerConfig) (err error) {
+
+	start := time.Now()
+	escalation := drainerConfig.Spec.Escalation
+
+	var forceDelete bool
+	var forceDeleteAfter time.Duration
+	if escalation != nil && escalation.ForceDeleteAfter != nil {
+		forceDelete = true
+		forceDeleteAfter = escalation.ForceDeleteAfter.Duration
+	}
+
+	if escalation != nil && escalation.ForceDeleteOnUnreachableNode {
+		unreachable, why, unreachableErr := r.isNodeUnreachable(ctx, shutdownHelper.Client, node)
+		if unreachableErr != nil {
+			r.logger.LogCtx(ctx, "level", "error", "message", fmt.Sprintf("could not tell whether %s node %s is unreachable: %s", typeOfNode, node.GetName(), unreachableErr))
+		}
+
+		if unreachable {
+			forceDelete = true
+			forceDeleteAfter = 0
+
+			message := fmt.Sprintf("Force deleting pods stuck terminating on unreachable node %s: %s", node.GetName(), why)
+			r.logger.LogCtx(ctx, "level", "warning", "message", fmt.Sprintf("force deleting pods stuck terminating on unreachable %s node %s: %s", typeOfNode, node.GetName(), why))
+			r.event.Warn(ctx, eventTarget, "NodeUnreachable", fmt.Sprintf("force deleting pods stuck terminating on unreachable %s node %s: %s", typeOfNode, node.GetName(), why))
+
+			updateErr := r.updateDrainerStatus(ctx, drainerConfig, drainerConfig.Status.NewEscalatedCondition(v1alpha2.ReasonNodeUnreachable, message))
+			if updateErr != nil {
+				r.logger.LogCtx(ctx, "level", "error", "message", fmt.Sprintf("failed to set drainer config status to escalated condition with error %s", updateErr))
+			}
+
+			// The volumes are only detached once no pod uses them anymore.
+			// Pods the drain did not wait for, as they got deleted long
+			// enough ago, may be left terminating still.
+			defer func() {
+				if err != nil {
+					return
+				}
+
+				r.forceDeletePods(ctx, shutdownHelper.Client, node, typeOfNode)
+				err = r.deleteVolumeAttachments(ctx, shutdownHelper.Client, node, typeOfNode)
+			}()
+		}
+	}
+
+	if forceDelete {
+		stop := r.forceDeleteTerminatingPods(ctx, eventTarget, shutdownHelper.Client, node, typeOfNode, drainerConfig, forceDeleteAfter)
+		defer stop()
+	}
+
+	window, ok := evictionWindow(shutdownHelper, escalation)
+	if !ok {
+		return drain.RunNodeDrain(&shutdownHelper, node.GetName())
+	}
+
+	// Evict the pods for the configured window first
+	evictionHelper := shutdownHelper
+	evictionHelper.Timeout = window
+
+	err = drain.RunNodeDrain(&evictionHelper, node.GetName())
+	if err == nil || ctx.Err() != nil || !isGlobalTimeout(err) {
+		return err
+	}
+
+	// Then delete the pods left
+	message := fmt.Sprintf("Deleting pods left on node %s after evicting them for %s", node.GetName(), window)
+	r.logger.LogCtx(ctx, "level", "warning", "message", fmt.Sprintf("deleting pods left on %s node %s after evicting them for %s", typeOfNode, node.GetName(), window))
+	r.event.Warn(ctx, eventTarget, "DrainingEscalated", fmt.Sprintf("deleting pods left on %s node %s after evicting them for %s", typeOfNode, node.GetName(), window))
+
+	err = r.updateDrainerStatus(ctx, drainerConfig, drainerConfig.Status.NewEscalatedCondition(v1alpha2.ReasonPodsDeleted, message))
+	if err != nil {
+		r.logger.LogCtx(ctx, "level", "error", "message", fmt.Sprintf("failed to set drainer config status to escalated condition with error %s", err))
+	}
+
+	shutdownHelper.DisableEviction = true
+	if shutdownHelper.Timeout != 0 {
+		shutdownHelper.Timeout -= time.Since(start)
+	}
+
+	return drain.RunNodeDrain(&shutdownHelper, node.GetName())
+}
+
+// evictionWindow returns how long pods are evicted before the pods left get
+// deleted instead. It returns false if pods are not to be deleted instead of
+// evicted, because the drain deletes them anyway, the drain times out before
+// or the drainer config does not ask for it.
+func evictionWindow(shutdownHelper drain.Helper, escalation *v1alpha2.DrainerConfigSpecEscalation) (time.Duration, bool) {
+	if escalation == nil || escalation.DeleteAfter == nil || shutdownHelper.DisableEviction {
+		return 0, false
+	}
+
+	window := escalation.DeleteAfter.Duration
+	if window <= 0 || (shutdownHelper.Timeout != 0 && window >= shutdownHelper.Timeout) {
+		return 0, false
+	}
+
+	return window, true
+}
+
+// Force deletes the pods stuck terminating on the node once the given amount
+// of time passed, and keeps doing so until the returned function is called.
+func (r *Resource) forceDeleteTerminatingPods(ctx context.Context,
+	eventTarget pkgruntime.Object,
+	k8sClient kubernetes.Interface,
+	node v1.Node, typeOfNode string,
+	drainerConfig v1alpha2.DrainerConfig,
+	after time.Duration) func() {
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		timer := time.NewTimer(after)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return
+		case <-done:
+			return
+		}
+
+		ticker := time.NewTicker(forceDeleteInterval)
+		defer ticker.Stop()
+
+		var escalated bool
+		for {
+			deleted := r.forceDeletePods(ctx, k8sClient, node, typeOfNode)
+			if len(deleted) > 0 && !escalated {
+				escalated = true
+
+				message := conditionMessage(fmt.Sprintf("Force deleted pods stuck terminating on node %s: %s", node.GetName(), strings.Join(deleted, ", ")))
+				r.event.Warn(ctx, eventTarget, "DrainingEscalated", fmt.Sprintf("force deleted %d pods stuck terminating on %s node %s", len(deleted), typeOfNode, node.GetName()))
+
+				err := r.updateDrainerStatus(ctx, drainerConfig, drainerConfig.Status.NewEscalatedCondition(v1alpha2.ReasonPodsForceDeleted, message))
+				if err != nil {
+					r.logger.LogCtx(ctx, "level", "error", "message", fmt.Sprintf("failed to set drainer config status to escalated condition with error %s", err))
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// Deletes the pods stuck terminating on the node with a grace period of zero
+// and returns their namespaced names
+func (r *Resource) forceDeletePods(ctx context.Context, k8sClient kubernetes.Interface, node v1.Node, typeOfNode string) []string {
+	pods, err := nodePods(k8sClient, ctx, &node)
+	if err != nil {
+		r.logger.LogCtx(ctx, "level", "error", "message", fmt.Sprintf("could not get the list of pods: %s", err))
+		return nil
+	}
+
+	var deleted []string
+	for _, p := range terminatingPods(pods) {
+		gracePeriodSeconds := int64(0)
+		err := k8sClient.CoreV1().Pods(p.Namespace).Delete(ctx, p.Name, metav1.DeleteOptions{GracePeriodSeconds: &gracePeriodSeconds})
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			r.logger.LogCtx(ctx, "level", "error", "message", fmt.Sprintf("failed to force delete pod %s/%s with error %s", p.Namespace, p.Name, err))
+			continue
+		}
+
+		r.logger.LogCtx(ctx, "level", "info", "message", fmt.Sprintf("force deleted pod %s/%s stuck terminating on %s node", p.Namespace, p.Name, typeOfNode))
+		deleted = append(deleted, p.Namespace+"/"+p.Name)
+	}
+
+	return deleted
+}
+
+// terminatingPods returns the given pods which got deleted, but did not
+// terminate yet. DaemonSet pods and pods the drain does not care about are
+// left out.
+func terminatingPods(pods []v1.Pod) []v1.Pod {
+	var terminating []v1.Pod
+	for _, p := range pods {
+		if p.DeletionTimestamp == nil || isIgnoredByDrain(p) {
+			continue
+		}
+		if controller := metav1.GetControllerOf(&p); controller != nil && controller.Kind == "DaemonSet" {
+			continue
+		}
+
+		terminating = append(terminating, p)
+	}
+
+	return terminating
+}
@@ -0,0 +1,102 @@
+package drainer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubectl/pkg/drain"
+
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+)
+
+func Test_evictionWindow(t *testing.T) {
+	escalation := &v1alpha2.DrainerConfigSpecEscalation{
+		DeleteAfter: &metav1.Duration{Duration: 5 * time.Minute},
+	}
+
+	testCases := []struct {
+		name           string
+		helper         drain.Helper
+		escalation     *v1alpha2.DrainerConfigSpecEscalation
+		expectedWindow time.Duration
+		expectedOK     bool
+	}{
+		{
+			name:       "case 0: drain without escalation is not staged",
+			helper:     drain.Helper{Timeout: 10 * time.Minute},
+			escalation: nil,
+			expectedOK: false,
+		},
+		{
+			name:           "case 1: pods are evicted for the configured window",
+			helper:         drain.Helper{Timeout: 10 * time.Minute},
+			escalation:     escalation,
+			expectedWindow: 5 * time.Minute,
+			expectedOK:     true,
+		},
+		{
+			name:           "case 2: drain without timeout is staged",
+			helper:         drain.Helper{},
+			escalation:     escalation,
+			expectedWindow: 5 * time.Minute,
+			expectedOK:     true,
+		},
+		{
+			name:       "case 3: drain timing out before the window ends is not staged",
+			helper:     drain.Helper{Timeout: 3 * time.Minute},
+			escalation: escalation,
+			expectedOK: false,
+		},
+		{
+			name:       "case 4: drain deleting pods anyway is not staged",
+			helper:     drain.Helper{DisableEviction: true, Timeout: 10 * time.Minute},
+			escalation: escalation,
+			expectedOK: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			window, ok := evictionWindow(tc.helper, tc.escalation)
+
+			if ok != tc.expectedOK {
+				t.Fatalf("ok == %t, expected %t", ok, tc.expectedOK)
+			}
+			if window != tc.expectedWindow {
+				t.Fatalf("window == %s, expected %s", window, tc.expectedWindow)
+			}
+		})
+	}
+}
+
+func Test_terminatingPods(t *testing.T) {
+	now := metav1.Now()
+	terminating := func(p v1.Pod) v1.Pod {
+		p.DeletionTimestamp = &now
+		return p
+	}
+
+	pods := []v1.Pod{
+		terminating(newTestPod("app", "web", "ReplicaSet", nil)),
+		newTestPod("app", "api", "ReplicaSet", nil),
+		terminating(newTestPod("kube-system", "node-exporter", "DaemonSet", nil)),
+		func() v1.Pod {
+			p := terminating(newTestPod("kube-system", "kube-proxy", "", nil))
+			p.Annotations = map[string]string{v1.MirrorPodAnnotationKey: "abc"}
+			return p
+		}(),
+	}
+
+	var names []string
+	for _, p := range terminatingPods(pods) {
+		names = append(names, p.Namespace+"/"+p.Name)
+	}
+
+	expected := []string{"app/web"}
+	if !cmp.Equal(expected, names) {
+		t.Fatalf("\n\n%s\n", cmp.Diff(expected, names))
+	}
+}
@@ -0,0 +1,67 @@
+package drainer
+
+import (
+	"strings"
+
+	"github.com/giantswarm/errors/tenant"
+	"github.com/giantswarm/microerror"
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+)
+
+// classifyDrainError masks the given drain error, telling apart drains which
+// were blocked by PodDisruptionBudgets and drains which timed out, based on the
+// pods left on the node.
+func classifyDrainError(err error, unevicted []v1alpha2.DrainerConfigStatusPod) error {
+	if tenant.IsAPINotAvailable(err) {
+		return microerror.Mask(err)
+	}
+
+	var blocked []string
+	for _, p := range unevicted {
+		if p.Reason == v1alpha2.UnevictedPodReasonPodDisruptionBudget {
+			blocked = append(blocked, p.Namespace+"/"+p.Name)
+		}
+	}
+	if len(blocked) > 0 {
+		return microerror.Maskf(podDisruptionBudgetBlockedError, "pods %s are protected by PodDisruptionBudgets: %s", strings.Join(blocked, ", "), err)
+	}
+
+	if isGlobalTimeout(err) {
+		return microerror.Maskf(drainTimeoutError, "%s", err)
+	}
+
+	return microerror.Mask(err)
+}
+
+// isGlobalTimeout checks whether the given error of kubectl drain tells that
+// the drain did not finish within its timeout. kubectl drain does not expose a
+// typed error for it.
+func isGlobalTimeout(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "global timeout reached")
+}
+
+// isPodDisruptionBudgetBlocked checks whether the given pod is selected by one
+// of the given PodDisruptionBudgets which does not allow any disruption.
+func isPodDisruptionBudgetBlocked(pod v1.Pod, pdbs []policyv1.PodDisruptionBudget) bool {
+	for _, pdb := range pdbs {
+		if pdb.Namespace != pod.Namespace || pdb.Status.DisruptionsAllowed > 0 {
+			continue
+		}
+
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil || selector.Empty() {
+			continue
+		}
+
+		if selector.Matches(labels.Set(pod.Labels)) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,86 @@
+package drainer
+
+import (
+	"time"
+
+	"github.com/giantswarm/microerror"
+
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+)
+
+// DrainPolicy is the effective set of parameters used to drain a node. It is
+// made of the operator defaults for the kind of node being drained, overridden
+// by whatever the DrainerConfig specifies in its drain policy.
+type DrainPolicy struct {
+	DeleteEmptyDirData              bool
+	DisableEviction                 bool
+	Force                           bool
+	GracePeriodSeconds              int
+	SkipWaitForDeleteTimeoutSeconds int
+	Timeout                         time.Duration
+}
+
+func (p DrainPolicy) validate() error {
+	if p.GracePeriodSeconds < -1 {
+		return microerror.Maskf(invalidDrainPolicyError, "grace period must be greater than or equal to -1, got %d", p.GracePeriodSeconds)
+	}
+	if p.SkipWaitForDeleteTimeoutSeconds < 0 {
+		return microerror.Maskf(invalidDrainPolicyError, "skip wait for delete timeout must not be negative, got %d", p.SkipWaitForDeleteTimeoutSeconds)
+	}
+	if p.Timeout < 0 {
+		return microerror.Maskf(invalidDrainPolicyError, "timeout must not be negative, got %s", p.Timeout)
+	}
+
+	return nil
+}
+
+// newDrainPolicy overrides the given defaults with all the fields set in the
+// drain policy of a DrainerConfig.
+func newDrainPolicy(defaults DrainPolicy, p *v1alpha2.DrainerConfigSpecPolicy) DrainPolicy {
+	if p == nil {
+		return defaults
+	}
+
+	policy := defaults
+	if p.DeleteEmptyDirData != nil {
+		policy.DeleteEmptyDirData = *p.DeleteEmptyDirData
+	}
+	if p.DisableEviction != nil {
+		policy.DisableEviction = *p.DisableEviction
+	}
+	if p.Force != nil {
+		policy.Force = *p.Force
+	}
+	if p.GracePeriodSeconds != nil {
+		policy.GracePeriodSeconds = *p.GracePeriodSeconds
+	}
+	if p.SkipWaitForDeleteTimeoutSeconds != nil {
+		policy.SkipWaitForDeleteTimeoutSeconds = *p.SkipWaitForDeleteTimeoutSeconds
+	}
+	if p.Timeout != nil {
+		policy.Timeout = p.Timeout.Duration
+	}
+
+	return policy
+}
+
+// ValidateDrainPolicy checks the drain policy of a DrainerConfig. The CRD
+// schema already covers most of it, but we do not want to rely on the schema
+// being up to date in every installation.
+func ValidateDrainPolicy(p *v1alpha2.DrainerConfigSpecPolicy) error {
+	if p == nil {
+		return nil
+	}
+
+	if p.GracePeriodSeconds != nil && *p.GracePeriodSeconds < -1 {
+		return microerror.Maskf(invalidDrainPolicyError, "gracePeriodSeconds must be greater than or equal to -1, got %d", *p.GracePeriodSeconds)
+	}
+	if p.SkipWaitForDeleteTimeoutSeconds != nil && *p.SkipWaitForDeleteTimeoutSeconds < 0 {
+		return microerror.Maskf(invalidDrainPolicyError, "skipWaitForDeleteTimeoutSeconds must not be negative, got %d", *p.SkipWaitForDeleteTimeoutSeconds)
+	}
+	if p.Timeout != nil && p.Timeout.Duration < 0 {
+		return microerror.Maskf(invalidDrainPolicyError, "timeout must not be negative, got %s", p.Timeout.Duration)
+	}
+
+	return nil
+}
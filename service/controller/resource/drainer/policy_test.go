@@ -0,0 +1,146 @@
+package drainer
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+)
+
+var (
+	testControlPlaneDrainPolicy = DrainPolicy{
+		DeleteEmptyDirData:              true,
+		DisableEviction:                 false,
+		Force:                           true,
+		GracePeriodSeconds:              45,
+		SkipWaitForDeleteTimeoutSeconds: 15,
+		Timeout:                         2 * time.Minute,
+	}
+	testWorkerDrainPolicy = DrainPolicy{
+		DeleteEmptyDirData:              true,
+		DisableEviction:                 false,
+		Force:                           true,
+		GracePeriodSeconds:              60,
+		SkipWaitForDeleteTimeoutSeconds: 15,
+		Timeout:                         5 * time.Minute,
+	}
+)
+
+func Test_newDrainPolicy(t *testing.T) {
+	testCases := []struct {
+		name           string
+		defaults       DrainPolicy
+		policy         *v1alpha2.DrainerConfigSpecPolicy
+		expectedPolicy DrainPolicy
+	}{
+		{
+			name:           "case 0: DrainerConfig without drain policy uses the defaults",
+			defaults:       testWorkerDrainPolicy,
+			policy:         nil,
+			expectedPolicy: testWorkerDrainPolicy,
+		},
+		{
+			name:     "case 1: DrainerConfig drain policy overrides the defaults",
+			defaults: testWorkerDrainPolicy,
+			policy: &v1alpha2.DrainerConfigSpecPolicy{
+				DisableEviction:    boolPtr(true),
+				GracePeriodSeconds: intPtr(600),
+				Timeout:            &metav1.Duration{Duration: 30 * time.Minute},
+			},
+			expectedPolicy: DrainPolicy{
+				DeleteEmptyDirData:              true,
+				DisableEviction:                 true,
+				Force:                           true,
+				GracePeriodSeconds:              600,
+				SkipWaitForDeleteTimeoutSeconds: 15,
+				Timeout:                         30 * time.Minute,
+			},
+		},
+		{
+			name:     "case 2: unset fields fall back to the control plane defaults",
+			defaults: testControlPlaneDrainPolicy,
+			policy: &v1alpha2.DrainerConfigSpecPolicy{
+				Force: boolPtr(false),
+			},
+			expectedPolicy: DrainPolicy{
+				DeleteEmptyDirData:              true,
+				DisableEviction:                 false,
+				Force:                           false,
+				GracePeriodSeconds:              45,
+				SkipWaitForDeleteTimeoutSeconds: 15,
+				Timeout:                         2 * time.Minute,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := newDrainPolicy(tc.defaults, tc.policy)
+			if p != tc.expectedPolicy {
+				t.Fatalf("newDrainPolicy() == %#v, expected %#v", p, tc.expectedPolicy)
+			}
+		})
+	}
+}
+
+func Test_ValidateDrainPolicy(t *testing.T) {
+	testCases := []struct {
+		name         string
+		policy       *v1alpha2.DrainerConfigSpecPolicy
+		errorMatcher func(error) bool
+	}{
+		{
+			name:         "case 0: missing drain policy is valid",
+			policy:       nil,
+			errorMatcher: nil,
+		},
+		{
+			name: "case 1: grace period of -1 is valid",
+			policy: &v1alpha2.DrainerConfigSpecPolicy{
+				GracePeriodSeconds: intPtr(-1),
+			},
+			errorMatcher: nil,
+		},
+		{
+			name: "case 2: grace period below -1 is invalid",
+			policy: &v1alpha2.DrainerConfigSpecPolicy{
+				GracePeriodSeconds: intPtr(-2),
+			},
+			errorMatcher: IsInvalidDrainPolicy,
+		},
+		{
+			name: "case 3: negative timeout is invalid",
+			policy: &v1alpha2.DrainerConfigSpecPolicy{
+				Timeout: &metav1.Duration{Duration: -time.Minute},
+			},
+			errorMatcher: IsInvalidDrainPolicy,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateDrainPolicy(tc.policy)
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+		})
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func intPtr(i int) *int {
+	return &i
+}
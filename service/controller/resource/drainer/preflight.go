@@ -0,0 +1,184 @@
+package drainer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+)
+
+// maxPreflightPods is the maximum number of pods listed per kind in the
+// preflight report, so that nodes running lots of pods do not blow up the
+// status.
+const maxPreflightPods = 20
+
+// Analyses the pods on the node before it gets cordoned and reports the pods
+// which need special treatment in the status and as an event. It returns
+// false if the drain must not start yet, because pods block it and the
+// drainer config asks to wait for them.
+func (r *Resource) preflight(ctx context.Context,
+	eventTarget pkgruntime.Object,
+	k8sClient kubernetes.Interface,
+	node v1.Node, typeOfNode string,
+	policy DrainPolicy,
+	drainerConfig v1alpha2.DrainerConfig) (bool, error) {
+
+	pods, err := nodePods(k8sClient, ctx, &node)
+	if err != nil {
+		return false, microerror.Mask(err)
+	}
+
+	pdbs, err := k8sClient.PolicyV1().PodDisruptionBudgets(v1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return false, microerror.Mask(err)
+	}
+
+	report := newPreflightReport(pods, pdbs.Items, policy, metav1.Now())
+
+	wait := report.Blocked && drainerConfig.Spec.Preflight != nil && drainerConfig.Spec.Preflight.WaitForBlockers
+	if wait {
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("waiting for pods blocking the drain of %s node %s", typeOfNode, node.GetName()))
+	}
+
+	// Every status update gets us reconciled again, so the report is only
+	// updated when it changed
+	if !preflightReportChanged(drainerConfig.Status.Preflight, report) {
+		return !wait, nil
+	}
+
+	message := preflightMessage(node.GetName(), report, policy)
+	if report.Blocked {
+		r.logger.LogCtx(ctx, "level", "warning", "message", message)
+		r.event.Warn(ctx, eventTarget, "DrainingBlocked", message)
+	} else {
+		r.logger.LogCtx(ctx, "level", "debug", "message", message)
+		r.event.Info(ctx, eventTarget, "PreflightSucceeded", message)
+	}
+
+	var conditions []metav1.Condition
+	if wait {
+		conditions = append(conditions, drainerConfig.Status.NewPendingCondition(v1alpha2.ReasonPreflightBlocked, conditionMessage(message)))
+	}
+
+	err = r.updateDrainerStatusFunc(ctx, drainerConfig, func(s *v1alpha2.DrainerConfigStatus) {
+		s.Preflight = &report
+	}, conditions...)
+	if err != nil {
+		return false, microerror.Mask(err)
+	}
+
+	return !wait, nil
+}
+
+// newPreflightReport sorts the given pods of a node into the kinds of pods
+// which need special treatment to be drained, and tells whether any of them
+// keeps the node from being drained with the given policy. Mirror pods and
+// finished pods are left out, as they never block a drain. DaemonSet pods are
+// listed but never block it either, since the drain helper always ignores
+// them.
+func newPreflightReport(pods []v1.Pod, pdbs []policyv1.PodDisruptionBudget, policy DrainPolicy, now metav1.Time) v1alpha2.DrainerConfigStatusPreflight {
+	var daemonSetPods, localStoragePods, pdbBlockedPods, unmanagedPods []string
+	for _, p := range pods {
+		if isIgnoredByDrain(p) {
+			continue
+		}
+
+		name := p.Namespace + "/" + p.Name
+
+		controller := metav1.GetControllerOf(&p)
+		if controller != nil && controller.Kind == "DaemonSet" {
+			daemonSetPods = append(daemonSetPods, name)
+			continue
+		}
+		if controller == nil {
+			unmanagedPods = append(unmanagedPods, name)
+		}
+		if hasEmptyDirVolume(p) {
+			localStoragePods = append(localStoragePods, name)
+		}
+		if isPodDisruptionBudgetBlocked(p, pdbs) {
+			pdbBlockedPods = append(pdbBlockedPods, name)
+		}
+	}
+
+	report := v1alpha2.DrainerConfigStatusPreflight{
+		Blocked: (len(localStoragePods) > 0 && !policy.DeleteEmptyDirData) ||
+			(len(pdbBlockedPods) > 0 && !policy.DisableEviction) ||
+			(len(unmanagedPods) > 0 && !policy.Force),
+		DaemonSetPods:                  limitPreflightPods(daemonSetPods),
+		LocalStoragePods:               limitPreflightPods(localStoragePods),
+		PodDisruptionBudgetBlockedPods: limitPreflightPods(pdbBlockedPods),
+		Time:                           now,
+		UnmanagedPods:                  limitPreflightPods(unmanagedPods),
+	}
+
+	return report
+}
+
+// Checks whether the result of the preflight analysis differs from the given
+// previous one, ignoring when either got done
+func preflightReportChanged(previous *v1alpha2.DrainerConfigStatusPreflight, report v1alpha2.DrainerConfigStatusPreflight) bool {
+	if previous == nil {
+		return true
+	}
+
+	p := *previous
+	p.Time = report.Time
+
+	return !apiequality.Semantic.DeepEqual(p, report)
+}
+
+// Describes the pods blocking the drain of the node in the given report
+func preflightMessage(nodeName string, report v1alpha2.DrainerConfigStatusPreflight, policy DrainPolicy) string {
+	var daemonSetPods string
+	if len(report.DaemonSetPods) > 0 {
+		daemonSetPods = fmt.Sprintf("; DaemonSet pods %s are left on the node", strings.Join(report.DaemonSetPods, ", "))
+	}
+
+	if !report.Blocked {
+		return fmt.Sprintf("No pods block the drain of node %s%s", nodeName, daemonSetPods)
+	}
+
+	var blockers []string
+	if len(report.PodDisruptionBudgetBlockedPods) > 0 && !policy.DisableEviction {
+		blockers = append(blockers, fmt.Sprintf("pods protected by PodDisruptionBudgets %s", strings.Join(report.PodDisruptionBudgetBlockedPods, ", ")))
+	}
+	if len(report.UnmanagedPods) > 0 && !policy.Force {
+		blockers = append(blockers, fmt.Sprintf("pods not managed by a controller %s", strings.Join(report.UnmanagedPods, ", ")))
+	}
+	if len(report.LocalStoragePods) > 0 && !policy.DeleteEmptyDirData {
+		blockers = append(blockers, fmt.Sprintf("pods using emptyDir volumes %s", strings.Join(report.LocalStoragePods, ", ")))
+	}
+
+	return fmt.Sprintf("Drain of node %s is blocked by %s%s", nodeName, strings.Join(blockers, "; "), daemonSetPods)
+}
+
+// Sorts the given pod names and keeps the first maxPreflightPods of them
+func limitPreflightPods(names []string) []string {
+	sort.Strings(names)
+	if len(names) > maxPreflightPods {
+		return names[:maxPreflightPods]
+	}
+
+	return names
+}
+
+func hasEmptyDirVolume(pod v1.Pod) bool {
+	for _, v := range pod.Spec.Volumes {
+		if v.EmptyDir != nil {
+			return true
+		}
+	}
+
+	return false
+}
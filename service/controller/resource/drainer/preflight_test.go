@@ -0,0 +1,164 @@
+package drainer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+)
+
+func Test_newPreflightReport(t *testing.T) {
+	now := metav1.NewTime(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	pods := []v1.Pod{
+		newTestPod("app", "web", "ReplicaSet", map[string]string{"app": "web"}),
+		newTestPod("app", "debug", "", nil),
+		newTestPod("kube-system", "node-exporter", "DaemonSet", nil),
+		func() v1.Pod {
+			p := newTestPod("app", "cache", "StatefulSet", nil)
+			p.Spec.Volumes = []v1.Volume{{Name: "data", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}}}
+			return p
+		}(),
+		func() v1.Pod {
+			p := newTestPod("kube-system", "kube-proxy", "", nil)
+			p.Annotations = map[string]string{v1.MirrorPodAnnotationKey: "abc"}
+			return p
+		}(),
+		func() v1.Pod {
+			p := newTestPod("app", "job", "", nil)
+			p.Status.Phase = v1.PodSucceeded
+			return p
+		}(),
+	}
+
+	pdbs := []policyv1.PodDisruptionBudget{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "app"},
+			Spec: policyv1.PodDisruptionBudgetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			},
+			Status: policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 0},
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		pdbs           []policyv1.PodDisruptionBudget
+		policy         DrainPolicy
+		expectedReport v1alpha2.DrainerConfigStatusPreflight
+	}{
+		{
+			name:   "case 0: pods protected by PodDisruptionBudgets, unmanaged pods and emptyDir pods block the drain",
+			pdbs:   pdbs,
+			policy: DrainPolicy{},
+			expectedReport: v1alpha2.DrainerConfigStatusPreflight{
+				Blocked:                        true,
+				DaemonSetPods:                  []string{"kube-system/node-exporter"},
+				LocalStoragePods:               []string{"app/cache"},
+				PodDisruptionBudgetBlockedPods: []string{"app/web"},
+				Time:                           now,
+				UnmanagedPods:                  []string{"app/debug"},
+			},
+		},
+		{
+			name:   "case 1: policy allowing to delete and force pods is not blocked",
+			pdbs:   pdbs,
+			policy: DrainPolicy{DeleteEmptyDirData: true, DisableEviction: true, Force: true},
+			expectedReport: v1alpha2.DrainerConfigStatusPreflight{
+				Blocked:                        false,
+				DaemonSetPods:                  []string{"kube-system/node-exporter"},
+				LocalStoragePods:               []string{"app/cache"},
+				PodDisruptionBudgetBlockedPods: []string{"app/web"},
+				Time:                           now,
+				UnmanagedPods:                  []string{"app/debug"},
+			},
+		},
+		{
+			name:   "case 2: PodDisruptionBudgets allowing disruptions do not block the drain",
+			pdbs:   []policyv1.PodDisruptionBudget{func() policyv1.PodDisruptionBudget { p := pdbs[0]; p.Status.DisruptionsAllowed = 1; return p }()},
+			policy: DrainPolicy{DeleteEmptyDirData: true, Force: true},
+			expectedReport: v1alpha2.DrainerConfigStatusPreflight{
+				Blocked:          false,
+				DaemonSetPods:    []string{"kube-system/node-exporter"},
+				LocalStoragePods: []string{"app/cache"},
+				Time:             now,
+				UnmanagedPods:    []string{"app/debug"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			report := newPreflightReport(pods, tc.pdbs, tc.policy, now)
+
+			if !cmp.Equal(tc.expectedReport, report) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.expectedReport, report))
+			}
+		})
+	}
+}
+
+func Test_preflightMessage(t *testing.T) {
+	testCases := []struct {
+		name            string
+		report          v1alpha2.DrainerConfigStatusPreflight
+		policy          DrainPolicy
+		expectedMessage string
+	}{
+		{
+			name: "case 0: DaemonSet pods are reported as left on the node",
+			report: v1alpha2.DrainerConfigStatusPreflight{
+				DaemonSetPods: []string{"kube-system/node-exporter"},
+			},
+			expectedMessage: "No pods block the drain of node ip-10-1-2-3; DaemonSet pods kube-system/node-exporter are left on the node",
+		},
+		{
+			name: "case 1: blockers the policy handles are not reported",
+			report: v1alpha2.DrainerConfigStatusPreflight{
+				Blocked:          true,
+				DaemonSetPods:    []string{"kube-system/node-exporter"},
+				LocalStoragePods: []string{"app/cache"},
+				UnmanagedPods:    []string{"app/debug"},
+			},
+			policy:          DrainPolicy{Force: true},
+			expectedMessage: "Drain of node ip-10-1-2-3 is blocked by pods using emptyDir volumes app/cache; DaemonSet pods kube-system/node-exporter are left on the node",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			message := preflightMessage("ip-10-1-2-3", tc.report, tc.policy)
+
+			if message != tc.expectedMessage {
+				t.Fatalf("message == %q, want %q", message, tc.expectedMessage)
+			}
+		})
+	}
+}
+
+func newTestPod(namespace, name, controllerKind string, labels map[string]string) v1.Pod {
+	p := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:    labels,
+			Name:      name,
+			Namespace: namespace,
+		},
+		Status: v1.PodStatus{
+			Phase: v1.PodRunning,
+		},
+	}
+
+	if controllerKind != "" {
+		controller := true
+		p.OwnerReferences = []metav1.OwnerReference{
+			{Controller: &controller, Kind: controllerKind, Name: name},
+		}
+	}
+
+	return p
+}
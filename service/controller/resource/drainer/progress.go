@@ -0,0 +1,136 @@
+package drainer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+)
+
+// drainProgressInterval is the minimum amount of time between two updates of
+// the drain progress in the status.
+const drainProgressInterval = 10 * time.Second
+
+// drainProgress counts the pods removed from a node while it is drained. It
+// is safe for concurrent use, as pods are evicted concurrently.
+type drainProgress struct {
+	mutex    sync.Mutex
+	progress v1alpha2.DrainerConfigStatusDrainProgress
+}
+
+func newDrainProgress(total int, now time.Time) *drainProgress {
+	p := &drainProgress{
+		progress: v1alpha2.DrainerConfigStatusDrainProgress{
+			LastProgressTime: metav1.NewTime(now),
+			Remaining:        total,
+			StartTime:        metav1.NewTime(now),
+			Total:            total,
+		},
+	}
+
+	return p
+}
+
+// podRemoved counts a pod which got evicted, or deleted if eviction was not
+// used.
+func (p *drainProgress) podRemoved(usingEviction bool, now time.Time) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if usingEviction {
+		p.progress.Evicted++
+	} else {
+		p.progress.Deleted++
+	}
+	p.progress.LastProgressTime = metav1.NewTime(now)
+
+	// Pods created on the node after the drain started are evicted too
+	p.progress.Remaining = p.progress.Total - p.progress.Evicted - p.progress.Deleted
+	if p.progress.Remaining < 0 {
+		p.progress.Remaining = 0
+	}
+}
+
+func (p *drainProgress) snapshot() v1alpha2.DrainerConfigStatusDrainProgress {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.progress
+}
+
+// Periodically records the given drain progress in the drainer config status
+// until the returned function is called, which records it one last time. The
+// progress is recorded when it changed, and at least every
+// drainingHeartbeatInterval otherwise, so that a drain stalling right away
+// still refreshes the heartbeat and the remaining pods. Recorded tells whether
+// the given progress got recorded already when the drain started.
+func (r *Resource) reportDrainProgress(ctx context.Context, drainerConfig v1alpha2.DrainerConfig, progress *drainProgress, recorded bool) func() {
+	reported := progress.snapshot()
+	var reportedAt time.Time
+	if recorded {
+		reportedAt = time.Now()
+	}
+	record := func() {
+		current := progress.snapshot()
+		now := time.Now()
+		if !drainProgressDue(reported, current, reportedAt, now) {
+			return
+		}
+
+		err := r.updateDrainerStatusFunc(ctx, drainerConfig, func(s *v1alpha2.DrainerConfigStatus) {
+			if s.Drain != nil {
+				s.Drain.Progress = &current
+			}
+		})
+		if err != nil {
+			r.logger.LogCtx(ctx, "level", "error", "message", fmt.Sprintf("failed to record drain progress with error %s", err))
+			return
+		}
+
+		reported = current
+		reportedAt = now
+	}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(drainProgressInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				record()
+			case <-ctx.Done():
+				return
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+
+		if ctx.Err() == nil {
+			record()
+		}
+	}
+}
+
+// Checks whether the current drain progress should be recorded, given the
+// progress recorded last and when. A zero time means nothing got recorded yet.
+func drainProgressDue(reported, current v1alpha2.DrainerConfigStatusDrainProgress, reportedAt, now time.Time) bool {
+	if reportedAt.IsZero() || current != reported {
+		return true
+	}
+
+	return now.Sub(reportedAt) >= drainingHeartbeatInterval
+}
@@ -0,0 +1,130 @@
+package drainer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+)
+
+func Test_drainProgress(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name             string
+		total            int
+		removed          []bool
+		expectedProgress v1alpha2.DrainerConfigStatusDrainProgress
+	}{
+		{
+			name:  "case 0: drain without removed pods made no progress",
+			total: 3,
+			expectedProgress: v1alpha2.DrainerConfigStatusDrainProgress{
+				LastProgressTime: metav1.NewTime(start),
+				Remaining:        3,
+				StartTime:        metav1.NewTime(start),
+				Total:            3,
+			},
+		},
+		{
+			name:    "case 1: evicted and deleted pods are counted apart",
+			total:   3,
+			removed: []bool{true, false},
+			expectedProgress: v1alpha2.DrainerConfigStatusDrainProgress{
+				Deleted:          1,
+				Evicted:          1,
+				LastProgressTime: metav1.NewTime(start.Add(2 * time.Minute)),
+				Remaining:        1,
+				StartTime:        metav1.NewTime(start),
+				Total:            3,
+			},
+		},
+		{
+			name:    "case 2: pods created after the drain started do not make remaining pods negative",
+			total:   1,
+			removed: []bool{true, true},
+			expectedProgress: v1alpha2.DrainerConfigStatusDrainProgress{
+				Evicted:          2,
+				LastProgressTime: metav1.NewTime(start.Add(2 * time.Minute)),
+				Remaining:        0,
+				StartTime:        metav1.NewTime(start),
+				Total:            1,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := newDrainProgress(tc.total, start)
+			for i, usingEviction := range tc.removed {
+				p.podRemoved(usingEviction, start.Add(time.Duration(i+1)*time.Minute))
+			}
+
+			progress := p.snapshot()
+			if !cmp.Equal(tc.expectedProgress, progress) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.expectedProgress, progress))
+			}
+		})
+	}
+}
+
+func Test_drainProgressDue(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	reported := v1alpha2.DrainerConfigStatusDrainProgress{
+		LastProgressTime: metav1.NewTime(start),
+		Remaining:        3,
+		StartTime:        metav1.NewTime(start),
+		Total:            3,
+	}
+	progressed := reported
+	progressed.Evicted = 1
+	progressed.Remaining = 2
+
+	testCases := []struct {
+		name        string
+		current     v1alpha2.DrainerConfigStatusDrainProgress
+		reportedAt  time.Time
+		now         time.Time
+		expectedDue bool
+	}{
+		{
+			name:        "case 0: progress never recorded is due",
+			current:     reported,
+			now:         start.Add(drainProgressInterval),
+			expectedDue: true,
+		},
+		{
+			name:        "case 1: unchanged progress recorded recently is not due",
+			current:     reported,
+			reportedAt:  start,
+			now:         start.Add(drainProgressInterval),
+			expectedDue: false,
+		},
+		{
+			name:        "case 2: changed progress is due",
+			current:     progressed,
+			reportedAt:  start,
+			now:         start.Add(drainProgressInterval),
+			expectedDue: true,
+		},
+		{
+			name:        "case 3: unchanged progress of a stalled drain is due for a heartbeat",
+			current:     reported,
+			reportedAt:  start,
+			now:         start.Add(drainingHeartbeatInterval),
+			expectedDue: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			due := drainProgressDue(reported, tc.current, tc.reportedAt, tc.now)
+			if due != tc.expectedDue {
+				t.Fatalf("due == %v, want %v", due, tc.expectedDue)
+			}
+		})
+	}
+}
@@ -1,13 +1,13 @@
 package drainer
 
 import (
-	"sync"
-
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
-	"github.com/giantswarm/tenantcluster/v6/pkg/tenantcluster"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/giantswarm/node-operator/service/clientcache"
+	"github.com/giantswarm/node-operator/service/eventtarget"
+	"github.com/giantswarm/node-operator/service/executor"
 	event "github.com/giantswarm/node-operator/service/recorder"
 )
 
@@ -15,47 +15,69 @@ const (
 	Name = "drainerv2"
 )
 
-var invalidConfigError = &microerror.Error{
-	Kind: "invalidConfigError",
-}
-
 type Config struct {
-	Client        client.Client
-	Event         event.Interface
-	Logger        micrologger.Logger
-	TenantCluster tenantcluster.Interface
-}
+	Client      client.Client
+	ClientCache clientcache.Interface
+	Event       event.Interface
+	EventTarget eventtarget.Interface
+	Executor    *executor.Executor
+	Logger      micrologger.Logger
 
-type NodeName = string
+	// ControlPlaneDrainPolicy is the default drain policy for control plane
+	// nodes.
+	ControlPlaneDrainPolicy DrainPolicy
+	// WorkerDrainPolicy is the default drain policy for worker nodes.
+	WorkerDrainPolicy DrainPolicy
+}
 
 type Resource struct {
-	client        client.Client
-	event         event.Interface
-	logger        micrologger.Logger
-	tenantCluster tenantcluster.Interface
+	client      client.Client
+	clientCache clientcache.Interface
+	event       event.Interface
+	eventTarget eventtarget.Interface
+	executor    *executor.Executor
+	logger      micrologger.Logger
 
-	lock     sync.RWMutex
-	draining map[NodeName]chan error
+	controlPlaneDrainPolicy DrainPolicy
+	workerDrainPolicy       DrainPolicy
 }
 
 func New(c Config) (*Resource, error) {
 	if c.Client == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.Client must not be empty", c)
 	}
+	if c.ClientCache == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.ClientCache must not be empty", c)
+	}
+	if c.EventTarget == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.EventTarget must not be empty", c)
+	}
+	if c.Executor == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Executor must not be empty", c)
+	}
 	if c.Logger == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", c)
 	}
-	if c.TenantCluster == nil {
-		return nil, microerror.Maskf(invalidConfigError, "%T.TenantCluster must not be empty", c)
+
+	err := c.ControlPlaneDrainPolicy.validate()
+	if err != nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.ControlPlaneDrainPolicy is invalid: %s", c, err)
+	}
+	err = c.WorkerDrainPolicy.validate()
+	if err != nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.WorkerDrainPolicy is invalid: %s", c, err)
 	}
 
 	r := &Resource{
-		client:        c.Client,
-		event:         c.Event,
-		logger:        c.Logger,
-		tenantCluster: c.TenantCluster,
-		lock:          sync.RWMutex{},
-		draining:      make(map[string]chan error),
+		client:      c.Client,
+		clientCache: c.ClientCache,
+		event:       c.Event,
+		eventTarget: c.EventTarget,
+		executor:    c.Executor,
+		logger:      c.Logger,
+
+		controlPlaneDrainPolicy: c.ControlPlaneDrainPolicy,
+		workerDrainPolicy:       c.WorkerDrainPolicy,
 	}
 
 	return r, nil
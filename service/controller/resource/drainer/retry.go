@@ -0,0 +1,104 @@
+package drainer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	pkgruntime "k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+	"github.com/giantswarm/node-operator/service/controller/key"
+)
+
+// maxRetryBackoff is the maximum time waited before a failed drain gets
+// retried.
+const maxRetryBackoff = time.Hour
+
+// Resets a failed drain, as requested by the retry annotation, so that it
+// gets started again, and removes the annotation afterwards. Drains which did
+// not fail are left as they are.
+func (r *Resource) retryDrain(ctx context.Context, eventTarget pkgruntime.Object, drainerConfig v1alpha2.DrainerConfig) error {
+	nodeName := key.NodeNameFromDrainerConfig(drainerConfig)
+
+	failed := drainerConfig.Status.HasTimeoutCondition() ||
+		(drainerConfig.Status.Drain != nil && drainerConfig.Status.Drain.Phase == v1alpha2.DrainPhaseFailed)
+
+	if failed && !drainerConfig.Spec.Cancel {
+		r.logger.LogCtx(ctx, "level", "info", "message", fmt.Sprintf("retrying drain of node %s as requested", nodeName))
+		r.event.Info(ctx, eventTarget, "DrainingRetried", fmt.Sprintf("retrying drain of node %s as requested", nodeName))
+
+		message := fmt.Sprintf("Retry of drain of node %s got requested", nodeName)
+		err := r.updateDrainerStatusFunc(ctx, drainerConfig,
+			func(s *v1alpha2.DrainerConfigStatus) {
+				if s.Drain != nil {
+					s.Drain.Failures = 0
+					s.Drain.Phase = v1alpha2.DrainPhaseFailed
+					s.Drain.RetryTime = nil
+				}
+			},
+			falseCondition(drainerConfig.Status.NewFailedCondition(v1alpha2.ReasonRetryRequested, message)),
+			falseCondition(drainerConfig.Status.NewTimeoutCondition(v1alpha2.ReasonRetryRequested, message)),
+			drainerConfig.Status.NewPendingCondition(v1alpha2.ReasonRetryRequested, message),
+		)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	} else {
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("drain of node %s did not fail, nothing to retry", nodeName))
+	}
+
+	// The annotation is only removed once the drain got reset, so that the
+	// retry is not lost. Resetting it again does no harm.
+	latest := &v1alpha2.DrainerConfig{}
+	err := r.client.Get(ctx, client.ObjectKeyFromObject(&drainerConfig), latest)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	patch := client.MergeFrom(latest.DeepCopy())
+	delete(latest.Annotations, v1alpha2.RetryAnnotation)
+
+	err = r.client.Patch(ctx, latest, patch)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("removed annotation %s", v1alpha2.RetryAnnotation))
+
+	return nil
+}
+
+// retryBackoff returns how long a drain which failed the given number of
+// times waits before it gets retried. The given backoff doubles with every
+// failure after the first one.
+func retryBackoff(backoff time.Duration, failures int) time.Duration {
+	for i := 1; i < failures; i++ {
+		backoff *= 2
+		if backoff >= maxRetryBackoff {
+			return maxRetryBackoff
+		}
+	}
+
+	if backoff > maxRetryBackoff {
+		return maxRetryBackoff
+	}
+
+	return backoff
+}
+
+// escalateDrainPolicy makes the given drain policy delete pods instead of
+// evicting them once the drain failed as many times as the drainer config
+// allows before escalating. It returns true if it did.
+func escalateDrainPolicy(policy DrainPolicy, drainerConfig v1alpha2.DrainerConfig) (DrainPolicy, bool) {
+	after := key.EscalateToDeleteAfterFromDrainerConfig(drainerConfig)
+	if after == 0 || policy.DisableEviction || drainerConfig.Status.Drain == nil || drainerConfig.Status.Drain.Failures < after {
+		return policy, false
+	}
+
+	policy.DisableEviction = true
+
+	return policy, true
+}
@@ -0,0 +1,99 @@
+package drainer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+)
+
+func Test_retryBackoff(t *testing.T) {
+	testCases := []struct {
+		name            string
+		backoff         time.Duration
+		failures        int
+		expectedBackoff time.Duration
+	}{
+		{
+			name:            "case 0: first retry waits for the backoff",
+			backoff:         time.Minute,
+			failures:        1,
+			expectedBackoff: time.Minute,
+		},
+		{
+			name:            "case 1: backoff doubles with every further failure",
+			backoff:         time.Minute,
+			failures:        4,
+			expectedBackoff: 8 * time.Minute,
+		},
+		{
+			name:            "case 2: backoff is limited",
+			backoff:         time.Minute,
+			failures:        100,
+			expectedBackoff: maxRetryBackoff,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			backoff := retryBackoff(tc.backoff, tc.failures)
+
+			if backoff != tc.expectedBackoff {
+				t.Fatalf("backoff == %s, expected %s", backoff, tc.expectedBackoff)
+			}
+		})
+	}
+}
+
+func Test_escalateDrainPolicy(t *testing.T) {
+	testCases := []struct {
+		name              string
+		retry             *v1alpha2.DrainerConfigSpecRetry
+		failures          int
+		expectedEscalated bool
+	}{
+		{
+			name:              "case 0: drain without retry policy is not escalated",
+			retry:             nil,
+			failures:          5,
+			expectedEscalated: false,
+		},
+		{
+			name:              "case 1: drain which did not fail often enough is not escalated",
+			retry:             &v1alpha2.DrainerConfigSpecRetry{EscalateToDeleteAfter: 2, MaxAttempts: 3},
+			failures:          1,
+			expectedEscalated: false,
+		},
+		{
+			name:              "case 2: drain which failed often enough deletes pods",
+			retry:             &v1alpha2.DrainerConfigSpecRetry{EscalateToDeleteAfter: 2, MaxAttempts: 3},
+			failures:          2,
+			expectedEscalated: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			drainerConfig := v1alpha2.DrainerConfig{
+				Spec: v1alpha2.DrainerConfigSpec{
+					Retry: tc.retry,
+				},
+				Status: v1alpha2.DrainerConfigStatus{
+					Drain: &v1alpha2.DrainerConfigStatusDrain{
+						Failures: tc.failures,
+						Phase:    v1alpha2.DrainPhaseFailed,
+					},
+				},
+			}
+
+			policy, escalated := escalateDrainPolicy(DrainPolicy{}, drainerConfig)
+
+			if escalated != tc.expectedEscalated {
+				t.Fatalf("escalated == %t, expected %t", escalated, tc.expectedEscalated)
+			}
+			if policy.DisableEviction != tc.expectedEscalated {
+				t.Fatalf("DisableEviction == %t, expected %t", policy.DisableEviction, tc.expectedEscalated)
+			}
+		})
+	}
+}
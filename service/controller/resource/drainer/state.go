@@ -0,0 +1,59 @@
+package drainer
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+)
+
+// nextDrain returns the persisted drain state for the drain about to be
+// started. A drain which was in flight, e.g. because the operator restarted in
+// between, is resumed and keeps its start time, so that its timeout is not
+// extended by the restart. The failed attempts are carried over, so that
+// retries are limited.
+func nextDrain(current *v1alpha2.DrainerConfigStatusDrain, now time.Time) *v1alpha2.DrainerConfigStatusDrain {
+	next := &v1alpha2.DrainerConfigStatusDrain{
+		Attempt:   1,
+		Phase:     v1alpha2.DrainPhaseCordoning,
+		StartTime: metav1.NewTime(now),
+	}
+
+	if current != nil {
+		next.Attempt = current.Attempt + 1
+		next.Failures = current.Failures
+
+		if current.Phase == v1alpha2.DrainPhaseCordoning || current.Phase == v1alpha2.DrainPhaseEvicting || current.Phase == v1alpha2.DrainPhaseDetaching {
+			next.StartTime = current.StartTime
+		}
+	}
+
+	return next
+}
+
+// remainingDrainTimeout returns the part of the given timeout which is left
+// for the given drain. It returns true if the timeout elapsed already. A
+// timeout of zero means no timeout.
+func remainingDrainTimeout(timeout time.Duration, d *v1alpha2.DrainerConfigStatusDrain, now time.Time) (time.Duration, bool) {
+	if timeout == 0 || d == nil {
+		return timeout, false
+	}
+
+	remaining := timeout - now.Sub(d.StartTime.Time)
+	if remaining <= 0 {
+		return 0, true
+	}
+
+	return remaining, false
+}
+
+// setDrainPhase returns a status mutation moving the persisted drain to the
+// given phase. Statuses without drain state are left untouched.
+func setDrainPhase(phase string) func(*v1alpha2.DrainerConfigStatus) {
+	return func(s *v1alpha2.DrainerConfigStatus) {
+		if s.Drain != nil {
+			s.Drain.Phase = phase
+		}
+	}
+}
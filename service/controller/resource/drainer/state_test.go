@@ -0,0 +1,161 @@
+package drainer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+)
+
+func Test_nextDrain(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	now := start.Add(10 * time.Minute)
+
+	testCases := []struct {
+		name          string
+		current       *v1alpha2.DrainerConfigStatusDrain
+		expectedDrain *v1alpha2.DrainerConfigStatusDrain
+	}{
+		{
+			name:    "case 0: first drain starts now",
+			current: nil,
+			expectedDrain: &v1alpha2.DrainerConfigStatusDrain{
+				Attempt:   1,
+				Phase:     v1alpha2.DrainPhaseCordoning,
+				StartTime: metav1.NewTime(now),
+			},
+		},
+		{
+			name: "case 1: drain interrupted while evicting keeps its start time",
+			current: &v1alpha2.DrainerConfigStatusDrain{
+				Attempt:   1,
+				Phase:     v1alpha2.DrainPhaseEvicting,
+				StartTime: metav1.NewTime(start),
+			},
+			expectedDrain: &v1alpha2.DrainerConfigStatusDrain{
+				Attempt:   2,
+				Phase:     v1alpha2.DrainPhaseCordoning,
+				StartTime: metav1.NewTime(start),
+			},
+		},
+		{
+			name: "case 2: drain interrupted while cordoning keeps its start time",
+			current: &v1alpha2.DrainerConfigStatusDrain{
+				Attempt:   3,
+				Phase:     v1alpha2.DrainPhaseCordoning,
+				StartTime: metav1.NewTime(start),
+			},
+			expectedDrain: &v1alpha2.DrainerConfigStatusDrain{
+				Attempt:   4,
+				Phase:     v1alpha2.DrainPhaseCordoning,
+				StartTime: metav1.NewTime(start),
+			},
+		},
+		{
+			name: "case 3: finished drain starts over now",
+			current: &v1alpha2.DrainerConfigStatusDrain{
+				Attempt:   1,
+				Phase:     v1alpha2.DrainPhaseCompleted,
+				StartTime: metav1.NewTime(start),
+			},
+			expectedDrain: &v1alpha2.DrainerConfigStatusDrain{
+				Attempt:   2,
+				Phase:     v1alpha2.DrainPhaseCordoning,
+				StartTime: metav1.NewTime(now),
+			},
+		}, {
+			name: "case 4: retried drain starts over now and keeps its failures",
+			current: &v1alpha2.DrainerConfigStatusDrain{
+				Attempt:   2,
+				Failures:  2,
+				Phase:     v1alpha2.DrainPhaseFailed,
+				RetryTime: &metav1.Time{Time: now},
+				StartTime: metav1.NewTime(start),
+			},
+			expectedDrain: &v1alpha2.DrainerConfigStatusDrain{
+				Attempt:   3,
+				Failures:  2,
+				Phase:     v1alpha2.DrainPhaseCordoning,
+				StartTime: metav1.NewTime(now),
+			},
+		},
+		{
+			name: "case 5: drain interrupted while detaching volumes keeps its start time",
+			current: &v1alpha2.DrainerConfigStatusDrain{
+				Attempt:   1,
+				Phase:     v1alpha2.DrainPhaseDetaching,
+				StartTime: metav1.NewTime(start),
+			},
+			expectedDrain: &v1alpha2.DrainerConfigStatusDrain{
+				Attempt:   2,
+				Phase:     v1alpha2.DrainPhaseCordoning,
+				StartTime: metav1.NewTime(start),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := nextDrain(tc.current, now)
+
+			if !cmp.Equal(tc.expectedDrain, d) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.expectedDrain, d))
+			}
+		})
+	}
+}
+
+func Test_remainingDrainTimeout(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	drain := &v1alpha2.DrainerConfigStatusDrain{
+		Attempt:   2,
+		Phase:     v1alpha2.DrainPhaseCordoning,
+		StartTime: metav1.NewTime(start),
+	}
+
+	testCases := []struct {
+		name              string
+		timeout           time.Duration
+		now               time.Time
+		expectedRemaining time.Duration
+		expectedExpired   bool
+	}{
+		{
+			name:              "case 0: zero timeout never expires",
+			timeout:           0,
+			now:               start.Add(24 * time.Hour),
+			expectedRemaining: 0,
+			expectedExpired:   false,
+		},
+		{
+			name:              "case 1: time spent before the restart is deducted",
+			timeout:           30 * time.Minute,
+			now:               start.Add(10 * time.Minute),
+			expectedRemaining: 20 * time.Minute,
+			expectedExpired:   false,
+		},
+		{
+			name:              "case 2: timeout elapsed during the restart",
+			timeout:           30 * time.Minute,
+			now:               start.Add(30 * time.Minute),
+			expectedRemaining: 0,
+			expectedExpired:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			remaining, expired := remainingDrainTimeout(tc.timeout, drain, tc.now)
+
+			if remaining != tc.expectedRemaining {
+				t.Fatalf("remaining == %s, expected %s", remaining, tc.expectedRemaining)
+			}
+			if expired != tc.expectedExpired {
+				t.Fatalf("expired == %t, expected %t", expired, tc.expectedExpired)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package drainer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/giantswarm/microerror"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+// Adds the given taints to the node, unless it has them already
+func (r *Resource) taintNode(ctx context.Context, k8sClient kubernetes.Interface, nodeName string, taints []v1.Taint) error {
+	if len(taints) == 0 {
+		return nil
+	}
+
+	err := r.updateNodeTaints(ctx, k8sClient, nodeName, func(node *v1.Node) bool {
+		return addTaints(node, taints, metav1.Now())
+	})
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("tainted node %s", nodeName))
+
+	return nil
+}
+
+// Removes the given taints from the node
+func (r *Resource) untaintNode(ctx context.Context, k8sClient kubernetes.Interface, nodeName string, taints []v1.Taint) error {
+	if len(taints) == 0 {
+		return nil
+	}
+
+	err := r.updateNodeTaints(ctx, k8sClient, nodeName, func(node *v1.Node) bool {
+		return removeTaints(node, taints)
+	})
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("removed taints from node %s", nodeName))
+
+	return nil
+}
+
+// Applies the given mutation to the latest version of the node and updates it
+// if the mutation changed anything
+func (r *Resource) updateNodeTaints(ctx context.Context, k8sClient kubernetes.Interface, nodeName string, mutate func(*v1.Node) bool) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		node, err := k8sClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		if !mutate(node) {
+			return nil
+		}
+
+		_, err = k8sClient.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// Adds the given taints the node does not have yet. Taints are matched by
+// key and effect. It returns true if the node changed.
+func addTaints(node *v1.Node, taints []v1.Taint, now metav1.Time) bool {
+	var changed bool
+	for _, t := range taints {
+		if hasTaint(node.Spec.Taints, t) {
+			continue
+		}
+
+		t := t
+		if t.Effect == v1.TaintEffectNoExecute && t.TimeAdded == nil {
+			t.TimeAdded = &now
+		}
+		node.Spec.Taints = append(node.Spec.Taints, t)
+		changed = true
+	}
+
+	return changed
+}
+
+// Removes the given taints from the node. Taints are matched by key and
+// effect. It returns true if the node changed.
+func removeTaints(node *v1.Node, taints []v1.Taint) bool {
+	var kept []v1.Taint
+	for _, t := range node.Spec.Taints {
+		if hasTaint(taints, t) {
+			continue
+		}
+		kept = append(kept, t)
+	}
+
+	if len(kept) == len(node.Spec.Taints) {
+		return false
+	}
+
+	node.Spec.Taints = kept
+
+	return true
+}
+
+func hasTaint(taints []v1.Taint, t v1.Taint) bool {
+	for _, existing := range taints {
+		if existing.MatchTaint(&t) {
+			return true
+		}
+	}
+
+	return false
+}
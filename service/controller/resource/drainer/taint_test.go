@@ -0,0 +1,103 @@
+package drainer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_addTaints(t *testing.T) {
+	now := metav1.NewTime(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	testCases := []struct {
+		name            string
+		existing        []v1.Taint
+		taints          []v1.Taint
+		expectedTaints  []v1.Taint
+		expectedChanged bool
+	}{
+		{
+			name:     "case 0: missing taints are added",
+			existing: []v1.Taint{{Key: "other", Effect: v1.TaintEffectNoSchedule}},
+			taints: []v1.Taint{
+				{Key: "quarantined", Effect: v1.TaintEffectNoSchedule},
+				{Key: "quarantined", Effect: v1.TaintEffectNoExecute},
+			},
+			expectedTaints: []v1.Taint{
+				{Key: "other", Effect: v1.TaintEffectNoSchedule},
+				{Key: "quarantined", Effect: v1.TaintEffectNoSchedule},
+				{Key: "quarantined", Effect: v1.TaintEffectNoExecute, TimeAdded: &now},
+			},
+			expectedChanged: true,
+		},
+		{
+			name:            "case 1: taints the node has already are not added again",
+			existing:        []v1.Taint{{Key: "quarantined", Value: "old", Effect: v1.TaintEffectNoSchedule}},
+			taints:          []v1.Taint{{Key: "quarantined", Value: "new", Effect: v1.TaintEffectNoSchedule}},
+			expectedTaints:  []v1.Taint{{Key: "quarantined", Value: "old", Effect: v1.TaintEffectNoSchedule}},
+			expectedChanged: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			node := &v1.Node{Spec: v1.NodeSpec{Taints: tc.existing}}
+
+			changed := addTaints(node, tc.taints, now)
+
+			if changed != tc.expectedChanged {
+				t.Fatalf("changed == %t, expected %t", changed, tc.expectedChanged)
+			}
+			if !cmp.Equal(tc.expectedTaints, node.Spec.Taints) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.expectedTaints, node.Spec.Taints))
+			}
+		})
+	}
+}
+
+func Test_removeTaints(t *testing.T) {
+	testCases := []struct {
+		name            string
+		existing        []v1.Taint
+		taints          []v1.Taint
+		expectedTaints  []v1.Taint
+		expectedChanged bool
+	}{
+		{
+			name: "case 0: given taints are removed, others are kept",
+			existing: []v1.Taint{
+				{Key: "other", Effect: v1.TaintEffectNoSchedule},
+				{Key: "quarantined", Effect: v1.TaintEffectNoSchedule},
+				{Key: "quarantined", Effect: v1.TaintEffectNoExecute},
+			},
+			taints:          []v1.Taint{{Key: "quarantined", Effect: v1.TaintEffectNoSchedule}},
+			expectedTaints:  []v1.Taint{{Key: "other", Effect: v1.TaintEffectNoSchedule}, {Key: "quarantined", Effect: v1.TaintEffectNoExecute}},
+			expectedChanged: true,
+		},
+		{
+			name:            "case 1: node without the given taints is left as it is",
+			existing:        []v1.Taint{{Key: "other", Effect: v1.TaintEffectNoSchedule}},
+			taints:          []v1.Taint{{Key: "quarantined", Effect: v1.TaintEffectNoSchedule}},
+			expectedTaints:  []v1.Taint{{Key: "other", Effect: v1.TaintEffectNoSchedule}},
+			expectedChanged: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			node := &v1.Node{Spec: v1.NodeSpec{Taints: tc.existing}}
+
+			changed := removeTaints(node, tc.taints)
+
+			if changed != tc.expectedChanged {
+				t.Fatalf("changed == %t, expected %t", changed, tc.expectedChanged)
+			}
+			if !cmp.Equal(tc.expectedTaints, node.Spec.Taints) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.expectedTaints, node.Spec.Taints))
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package drainer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+)
+
+// maxUnevictedPods is the maximum number of pods left on the node which are
+// recorded in the status.
+const maxUnevictedPods = 20
+
+// Logs the pods left on the node after its drain failed and records them in
+// the drainer config status. Every pod gets logged, but only a single event
+// is emitted, as the status tells which pods are left.
+func (r *Resource) recordUnevictedPods(k8sClient kubernetes.Interface, ctx context.Context, eventTarget pkgruntime.Object, typeOfNode string, node *v1.Node, drainerConfig v1alpha2.DrainerConfig) []v1alpha2.DrainerConfigStatusPod {
+	// Get the list of pods for the specific node
+	pods, err := nodePods(k8sClient, ctx, node)
+	if err != nil {
+		r.logger.LogCtx(ctx, "level", "error", "message", fmt.Sprintf("could not get the list of pods: %s", err))
+		r.event.Warn(ctx, eventTarget, "DrainerConfigFailed", fmt.Sprintf("could not get the list of pods for the node %s: %s", node.GetName(), err))
+		return nil
+	}
+
+	// Without PodDisruptionBudgets we can still tell the other reasons apart
+	pdbs, err := k8sClient.PolicyV1().PodDisruptionBudgets(v1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		r.logger.LogCtx(ctx, "level", "error", "message", fmt.Sprintf("could not get the list of pod disruption budgets: %s", err))
+		pdbs = &policyv1.PodDisruptionBudgetList{}
+	}
+
+	unevicted := newUnevictedPods(pods, pdbs.Items)
+	if len(unevicted) == 0 {
+		return nil
+	}
+
+	for _, p := range unevicted {
+		r.logger.LogCtx(ctx, "level", "info", "message", fmt.Sprintf("could not evict/delete pod %s/%s on %s node: %s", p.Namespace, p.Name, typeOfNode, p.Reason))
+	}
+	r.event.Warn(ctx, eventTarget, "DrainerConfigFailed", fmt.Sprintf("%s node %s could not evict/delete %d pods", typeOfNode, node.GetName(), len(unevicted)))
+
+	err = r.updateDrainerStatusFunc(ctx, drainerConfig, func(s *v1alpha2.DrainerConfigStatus) {
+		s.UnevictedPods = limitUnevictedPods(unevicted)
+	})
+	if err != nil {
+		r.logger.LogCtx(ctx, "level", "error", "message", fmt.Sprintf("failed to record unevicted pods with error %s", err))
+	}
+
+	return unevicted
+}
+
+// newUnevictedPods returns the given pods left on a node after its drain
+// failed, together with the reason each of them was presumably not evicted.
+// Pods which the drain leaves on the node on purpose are left out.
+func newUnevictedPods(pods []v1.Pod, pdbs []policyv1.PodDisruptionBudget) []v1alpha2.DrainerConfigStatusPod {
+	var unevicted []v1alpha2.DrainerConfigStatusPod
+	for _, p := range pods {
+		if isIgnoredByDrain(p) {
+			continue
+		}
+
+		var ownerKind string
+		if controller := metav1.GetControllerOf(&p); controller != nil {
+			ownerKind = controller.Kind
+		}
+		if ownerKind == "DaemonSet" {
+			continue
+		}
+
+		var reason string
+		switch {
+		case p.DeletionTimestamp != nil:
+			reason = v1alpha2.UnevictedPodReasonTerminating
+		case isPodDisruptionBudgetBlocked(p, pdbs):
+			reason = v1alpha2.UnevictedPodReasonPodDisruptionBudget
+		case ownerKind == "":
+			reason = v1alpha2.UnevictedPodReasonUnmanaged
+		case hasEmptyDirVolume(p):
+			reason = v1alpha2.UnevictedPodReasonLocalStorage
+		default:
+			reason = v1alpha2.UnevictedPodReasonUnknown
+		}
+
+		unevicted = append(unevicted, v1alpha2.DrainerConfigStatusPod{
+			Name:      p.Name,
+			Namespace: p.Namespace,
+			OwnerKind: ownerKind,
+			Reason:    reason,
+		})
+	}
+
+	sort.Slice(unevicted, func(i, j int) bool {
+		if unevicted[i].Namespace != unevicted[j].Namespace {
+			return unevicted[i].Namespace < unevicted[j].Namespace
+		}
+		return unevicted[i].Name < unevicted[j].Name
+	})
+
+	return unevicted
+}
+
+// Keeps the first maxUnevictedPods of the given pods
+func limitUnevictedPods(unevicted []v1alpha2.DrainerConfigStatusPod) []v1alpha2.DrainerConfigStatusPod {
+	if len(unevicted) > maxUnevictedPods {
+		return unevicted[:maxUnevictedPods]
+	}
+
+	return unevicted
+}
+
+// Checks whether the drain does not care about the given pod, because it is a
+// mirror pod or it finished already
+func isIgnoredByDrain(pod v1.Pod) bool {
+	if _, ok := pod.Annotations[v1.MirrorPodAnnotationKey]; ok {
+		return true
+	}
+
+	return pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed
+}
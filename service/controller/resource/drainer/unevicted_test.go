@@ -0,0 +1,83 @@
+package drainer
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+)
+
+func Test_newUnevictedPods(t *testing.T) {
+	testCases := []struct {
+		name              string
+		pods              []v1.Pod
+		pdbs              []policyv1.PodDisruptionBudget
+		expectedUnevicted []v1alpha2.DrainerConfigStatusPod
+	}{
+		{
+			name: "case 0: pods are sorted and get the reason they were presumably not evicted",
+			pods: []v1.Pod{
+				newTestPod("app", "web", "ReplicaSet", map[string]string{"app": "web"}),
+				func() v1.Pod {
+					p := newTestPod("app", "api", "ReplicaSet", map[string]string{"app": "web"})
+					now := metav1.Now()
+					p.DeletionTimestamp = &now
+					return p
+				}(),
+				newTestPod("app", "debug", "", nil),
+				func() v1.Pod {
+					p := newTestPod("app", "cache", "StatefulSet", nil)
+					p.Spec.Volumes = []v1.Volume{{Name: "data", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}}}
+					return p
+				}(),
+				newTestPod("batch", "worker", "ReplicaSet", nil),
+			},
+			pdbs: []policyv1.PodDisruptionBudget{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "app"},
+					Spec: policyv1.PodDisruptionBudgetSpec{
+						Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+					},
+				},
+			},
+			expectedUnevicted: []v1alpha2.DrainerConfigStatusPod{
+				{Name: "api", Namespace: "app", OwnerKind: "ReplicaSet", Reason: v1alpha2.UnevictedPodReasonTerminating},
+				{Name: "cache", Namespace: "app", OwnerKind: "StatefulSet", Reason: v1alpha2.UnevictedPodReasonLocalStorage},
+				{Name: "debug", Namespace: "app", Reason: v1alpha2.UnevictedPodReasonUnmanaged},
+				{Name: "web", Namespace: "app", OwnerKind: "ReplicaSet", Reason: v1alpha2.UnevictedPodReasonPodDisruptionBudget},
+				{Name: "worker", Namespace: "batch", OwnerKind: "ReplicaSet", Reason: v1alpha2.UnevictedPodReasonUnknown},
+			},
+		},
+		{
+			name: "case 1: pods left on the node on purpose are not reported",
+			pods: []v1.Pod{
+				newTestPod("kube-system", "node-exporter", "DaemonSet", nil),
+				func() v1.Pod {
+					p := newTestPod("kube-system", "kube-proxy", "", nil)
+					p.Annotations = map[string]string{v1.MirrorPodAnnotationKey: "abc"}
+					return p
+				}(),
+				func() v1.Pod {
+					p := newTestPod("app", "job", "Job", nil)
+					p.Status.Phase = v1.PodFailed
+					return p
+				}(),
+			},
+			expectedUnevicted: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			unevicted := newUnevictedPods(tc.pods, tc.pdbs)
+
+			if !cmp.Equal(tc.expectedUnevicted, unevicted) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.expectedUnevicted, unevicted))
+			}
+		})
+	}
+}
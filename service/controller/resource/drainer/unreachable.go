@@ -0,0 +1,95 @@
+package drainer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// nodeLeaseGracePeriod is the amount of time after which a node whose lease
+// was not renewed is considered unreachable. It matches the default node
+// monitor grace period of the kube-controller-manager.
+const nodeLeaseGracePeriod = 40 * time.Second
+
+// Checks whether the kubelet of the given node stopped reporting, based on
+// the node status and its lease. It returns why the node is considered
+// unreachable.
+func (r *Resource) isNodeUnreachable(ctx context.Context, k8sClient kubernetes.Interface, node v1.Node) (bool, string, error) {
+	lease, err := k8sClient.CoordinationV1().Leases(v1.NamespaceNodeLease).Get(ctx, node.GetName(), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		lease = nil
+	} else if err != nil {
+		return false, "", microerror.Mask(err)
+	}
+
+	unreachable, reason := nodeUnreachable(node, lease, time.Now())
+
+	return unreachable, reason, nil
+}
+
+// Deletes the VolumeAttachments of the given node, so that the volumes can be
+// attached to other nodes without waiting for the unreachable node to detach
+// them
+func (r *Resource) deleteVolumeAttachments(ctx context.Context, k8sClient kubernetes.Interface, node v1.Node, typeOfNode string) error {
+	volumeAttachments, err := k8sClient.StorageV1().VolumeAttachments().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	for _, name := range nodeVolumeAttachments(volumeAttachments.Items, node.GetName()) {
+		err := k8sClient.StorageV1().VolumeAttachments().Delete(ctx, name, metav1.DeleteOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.LogCtx(ctx, "level", "info", "message", fmt.Sprintf("deleted volume attachment %s of unreachable %s node %s", name, typeOfNode, node.GetName()))
+	}
+
+	return nil
+}
+
+// nodeUnreachable checks whether the kubelet of the given node stopped
+// reporting. Nodes which are NotReady, but whose kubelet still renews its
+// lease, are reachable, as their kubelet still terminates pods. Nodes which
+// are NotReady for longer than the grace period without any lease are
+// unreachable, as nothing tells their kubelet is still around.
+func nodeUnreachable(node v1.Node, lease *coordinationv1.Lease, now time.Time) (bool, string) {
+	for _, t := range node.Spec.Taints {
+		if t.Key == v1.TaintNodeUnreachable {
+			return true, "node is tainted as unreachable"
+		}
+	}
+
+	var ready *v1.NodeCondition
+	for i := range node.Status.Conditions {
+		if node.Status.Conditions[i].Type == v1.NodeReady {
+			ready = &node.Status.Conditions[i]
+		}
+	}
+	if ready == nil || ready.Status == v1.ConditionUnknown {
+		return true, "node status is unknown"
+	}
+
+	if lease == nil || lease.Spec.RenewTime == nil {
+		if ready.Status == v1.ConditionFalse && now.Sub(ready.LastTransitionTime.Time) > nodeLeaseGracePeriod {
+			return true, fmt.Sprintf("node is NotReady since %s and has no lease", ready.LastTransitionTime.UTC().Format(time.RFC3339))
+		}
+
+		return false, ""
+	}
+
+	if now.Sub(lease.Spec.RenewTime.Time) > nodeLeaseGracePeriod {
+		return true, fmt.Sprintf("node lease was last renewed at %s", lease.Spec.RenewTime.UTC().Format(time.RFC3339))
+	}
+
+	return false, ""
+}
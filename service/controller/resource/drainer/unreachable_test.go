@@ -0,0 +1,104 @@
+package drainer
+
+import (
+	"testing"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_nodeUnreachable(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	newNode := func(ready v1.ConditionStatus) v1.Node {
+		return v1.Node{
+			Status: v1.NodeStatus{
+				Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: ready}},
+			},
+		}
+	}
+	newLease := func(renewed time.Time) *coordinationv1.Lease {
+		renewTime := metav1.NewMicroTime(renewed)
+		return &coordinationv1.Lease{Spec: coordinationv1.LeaseSpec{RenewTime: &renewTime}}
+	}
+
+	testCases := []struct {
+		name                string
+		node                v1.Node
+		lease               *coordinationv1.Lease
+		expectedUnreachable bool
+	}{
+		{
+			name:                "case 0: ready node renewing its lease is reachable",
+			node:                newNode(v1.ConditionTrue),
+			lease:               newLease(now.Add(-10 * time.Second)),
+			expectedUnreachable: false,
+		},
+		{
+			name:                "case 1: NotReady node renewing its lease is reachable",
+			node:                newNode(v1.ConditionFalse),
+			lease:               newLease(now.Add(-10 * time.Second)),
+			expectedUnreachable: false,
+		},
+		{
+			name:                "case 2: node with unknown status is unreachable",
+			node:                newNode(v1.ConditionUnknown),
+			lease:               nil,
+			expectedUnreachable: true,
+		},
+		{
+			name:                "case 3: node with a stale lease is unreachable",
+			node:                newNode(v1.ConditionTrue),
+			lease:               newLease(now.Add(-5 * time.Minute)),
+			expectedUnreachable: true,
+		},
+		{
+			name: "case 4: node tainted as unreachable is unreachable",
+			node: func() v1.Node {
+				n := newNode(v1.ConditionTrue)
+				n.Spec.Taints = []v1.Taint{{Key: v1.TaintNodeUnreachable, Effect: v1.TaintEffectNoExecute}}
+				return n
+			}(),
+			lease:               nil,
+			expectedUnreachable: true,
+		},
+		{
+			name: "case 5: NotReady node without a lease past the grace period is unreachable",
+			node: func() v1.Node {
+				n := newNode(v1.ConditionFalse)
+				n.Status.Conditions[0].LastTransitionTime = metav1.NewTime(now.Add(-5 * time.Minute))
+				return n
+			}(),
+			lease:               nil,
+			expectedUnreachable: true,
+		},
+		{
+			name: "case 6: NotReady node without a lease within the grace period is reachable",
+			node: func() v1.Node {
+				n := newNode(v1.ConditionFalse)
+				n.Status.Conditions[0].LastTransitionTime = metav1.NewTime(now.Add(-10 * time.Second))
+				return n
+			}(),
+			lease:               nil,
+			expectedUnreachable: false,
+		},
+		{
+			name:                "case 7: ready node without a lease is reachable",
+			node:                newNode(v1.ConditionTrue),
+			lease:               nil,
+			expectedUnreachable: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			unreachable, reason := nodeUnreachable(tc.node, tc.lease, now)
+
+			if unreachable != tc.expectedUnreachable {
+				t.Fatalf("unreachable == %t, expected %t (%s)", unreachable, tc.expectedUnreachable, reason)
+			}
+		})
+	}
+}
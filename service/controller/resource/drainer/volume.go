@@ -0,0 +1,93 @@
+package drainer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+	"github.com/giantswarm/node-operator/service/controller/key"
+)
+
+// volumeDetachInterval is the amount of time between two checks whether the
+// volumes of a drained node got detached.
+const volumeDetachInterval = 5 * time.Second
+
+// Waits for the VolumeAttachments of the given node to be gone, as configured
+// in spec.volumeDetach. It fails once the configured timeout elapsed.
+func (r *Resource) waitForVolumeDetach(ctx context.Context,
+	eventTarget pkgruntime.Object,
+	k8sClient kubernetes.Interface,
+	node v1.Node, typeOfNode string,
+	drainerConfig v1alpha2.DrainerConfig) error {
+
+	timeout := key.VolumeDetachTimeoutFromDrainerConfig(drainerConfig)
+
+	message := fmt.Sprintf("Waiting up to %s for the volumes of node %s to be detached", timeout, node.GetName())
+	r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("waiting up to %s for the volumes of %s node %s to be detached", timeout, typeOfNode, node.GetName()))
+
+	err := r.updateDrainerStatusFunc(ctx, drainerConfig, setDrainPhase(v1alpha2.DrainPhaseDetaching),
+		drainerConfig.Status.NewDrainingCondition(v1alpha2.ReasonWaitingForVolumeDetach, message),
+	)
+	if err != nil {
+		r.logger.LogCtx(ctx, "level", "error", "message", fmt.Sprintf("failed to set drainer config status to detaching phase with error %s", err))
+	}
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	ticker := time.NewTicker(volumeDetachInterval)
+	defer ticker.Stop()
+
+	var attached []string
+	for {
+		volumeAttachments, err := k8sClient.StorageV1().VolumeAttachments().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			// Listing may fail temporarily, so keep trying until the
+			// timeout elapsed
+			r.logger.LogCtx(ctx, "level", "warning", "message", fmt.Sprintf("failed to list volume attachments of %s node %s with error %s", typeOfNode, node.GetName(), err))
+		} else {
+			attached = nodeVolumeAttachments(volumeAttachments.Items, node.GetName())
+			if len(attached) == 0 {
+				r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("volumes of %s node %s are detached", typeOfNode, node.GetName()))
+				return nil
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-deadline.C:
+			r.logger.LogCtx(ctx, "level", "error", "message", fmt.Sprintf("volume attachments %s of %s node %s still exist after %s", strings.Join(attached, ", "), typeOfNode, node.GetName(), timeout))
+			r.event.Warn(ctx, eventTarget, "DrainingFailed", fmt.Sprintf("volumes of %s node %s were not detached within %s", typeOfNode, node.GetName(), timeout))
+
+			return microerror.Maskf(volumeDetachTimeoutError, "volume attachments %s still exist after %s", strings.Join(attached, ", "), timeout)
+		case <-ctx.Done():
+			r.logger.LogCtx(ctx, "level", "info", "message", fmt.Sprintf("stopped waiting for the volumes of %s node %s to be detached", typeOfNode, node.GetName()))
+			return microerror.Maskf(drainCanceledError, "%s", ctx.Err())
+		}
+	}
+}
+
+// nodeVolumeAttachments returns the sorted names of the VolumeAttachments of
+// the given node.
+func nodeVolumeAttachments(volumeAttachments []storagev1.VolumeAttachment, nodeName string) []string {
+	var names []string
+	for _, va := range volumeAttachments {
+		if va.Spec.NodeName == nodeName {
+			names = append(names, va.Name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,56 @@
+package drainer
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_nodeVolumeAttachments(t *testing.T) {
+	newVolumeAttachment := func(name, nodeName string) storagev1.VolumeAttachment {
+		return storagev1.VolumeAttachment{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       storagev1.VolumeAttachmentSpec{NodeName: nodeName},
+		}
+	}
+
+	testCases := []struct {
+		name              string
+		volumeAttachments []storagev1.VolumeAttachment
+		expectedNames     []string
+	}{
+		{
+			name:              "case 0: no volume attachments",
+			volumeAttachments: nil,
+			expectedNames:     nil,
+		},
+		{
+			name: "case 1: only volume attachments of the node are returned, sorted",
+			volumeAttachments: []storagev1.VolumeAttachment{
+				newVolumeAttachment("csi-b", "node-1"),
+				newVolumeAttachment("csi-c", "node-2"),
+				newVolumeAttachment("csi-a", "node-1"),
+			},
+			expectedNames: []string{"csi-a", "csi-b"},
+		},
+		{
+			name: "case 2: volume attachments of other nodes only",
+			volumeAttachments: []storagev1.VolumeAttachment{
+				newVolumeAttachment("csi-c", "node-2"),
+			},
+			expectedNames: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			names := nodeVolumeAttachments(tc.volumeAttachments, "node-1")
+
+			if !cmp.Equal(tc.expectedNames, names) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.expectedNames, names))
+			}
+		})
+	}
+}
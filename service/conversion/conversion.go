@@ -0,0 +1,227 @@
+// Package conversion converts DrainerConfigs between the API versions served
+// by the DrainerConfig CRD and configures the CRD to call the conversion
+// webhook.
+package conversion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/giantswarm/k8sclient/v7/pkg/k8sclient"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	corev1alpha1 "github.com/giantswarm/node-operator/api"
+	corev1alpha2 "github.com/giantswarm/node-operator/api/v1alpha2"
+)
+
+const (
+	// CRDName is the name of the DrainerConfig CRD.
+	CRDName = "drainerconfigs.core.giantswarm.io"
+	// Path is the URL path the conversion webhook is served at.
+	Path = "/convert"
+)
+
+type Config struct {
+	K8sClient k8sclient.Interface
+	Logger    micrologger.Logger
+
+	// CAFile is the file path of the certificate authority the Kubernetes
+	// API server verifies the webhook with.
+	CAFile string
+	// ServiceName is the name of the Service the Kubernetes API server
+	// reaches the webhook through. When empty the CRD is left as it is.
+	ServiceName      string
+	ServiceNamespace string
+	ServicePort      int32
+}
+
+type Service struct {
+	k8sClient k8sclient.Interface
+	logger    micrologger.Logger
+
+	caFile           string
+	serviceName      string
+	serviceNamespace string
+	servicePort      int32
+}
+
+func New(config Config) (*Service, error) {
+	if config.K8sClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.K8sClient must not be empty", config)
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+
+	if config.ServiceName != "" {
+		if config.CAFile == "" {
+			return nil, microerror.Maskf(invalidConfigError, "%T.CAFile must not be empty", config)
+		}
+		if config.ServiceNamespace == "" {
+			return nil, microerror.Maskf(invalidConfigError, "%T.ServiceNamespace must not be empty", config)
+		}
+		if config.ServicePort <= 0 {
+			return nil, microerror.Maskf(invalidConfigError, "%T.ServicePort must be greater than 0", config)
+		}
+	}
+
+	s := &Service{
+		k8sClient: config.K8sClient,
+		logger:    config.Logger,
+
+		caFile:           config.CAFile,
+		serviceName:      config.ServiceName,
+		serviceNamespace: config.ServiceNamespace,
+		servicePort:      config.ServicePort,
+	}
+
+	return s, nil
+}
+
+// Convert converts the given DrainerConfigs to the desired API version.
+func (s *Service) Convert(ctx context.Context, objects []runtime.RawExtension, desiredAPIVersion string) ([]runtime.RawExtension, error) {
+	var converted []runtime.RawExtension
+
+	for _, o := range objects {
+		var typeMeta metav1.TypeMeta
+		err := json.Unmarshal(o.Raw, &typeMeta)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		src, err := newDrainerConfig(typeMeta)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		err = json.Unmarshal(o.Raw, src)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		dst, err := newDrainerConfig(metav1.TypeMeta{APIVersion: desiredAPIVersion, Kind: typeMeta.Kind})
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		err = convert(src, dst)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		b, err := json.Marshal(dst)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		converted = append(converted, runtime.RawExtension{Raw: b})
+	}
+
+	return converted, nil
+}
+
+// EnsureCRD configures the DrainerConfig CRD to convert objects using the
+// conversion webhook.
+func (s *Service) EnsureCRD(ctx context.Context) error {
+	if s.serviceName == "" {
+		s.logger.LogCtx(ctx, "level", "debug", "message", "not configuring CRD conversion webhook", "reason", "webhook service is not configured")
+		return nil
+	}
+
+	caBundle, err := os.ReadFile(s.caFile)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	s.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("configuring conversion webhook of CRD %s", CRDName))
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		crd, err := s.k8sClient.ExtClient().ApiextensionsV1().CustomResourceDefinitions().Get(ctx, CRDName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		path := Path
+		crd.Spec.Conversion = &apiextensionsv1.CustomResourceConversion{
+			Strategy: apiextensionsv1.WebhookConverter,
+			Webhook: &apiextensionsv1.WebhookConversion{
+				ClientConfig: &apiextensionsv1.WebhookClientConfig{
+					CABundle: caBundle,
+					Service: &apiextensionsv1.ServiceReference{
+						Name:      s.serviceName,
+						Namespace: s.serviceNamespace,
+						Path:      &path,
+						Port:      &s.servicePort,
+					},
+				},
+				ConversionReviewVersions: []string{"v1"},
+			},
+		}
+
+		_, err = s.k8sClient.ExtClient().ApiextensionsV1().CustomResourceDefinitions().Update(ctx, crd, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	s.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("configured conversion webhook of CRD %s", CRDName))
+
+	return nil
+}
+
+// convert converts src into dst, going through the hub version if neither of
+// them is the hub.
+func convert(src, dst runtime.Object) error {
+	switch s := src.(type) {
+	case conversion.Hub:
+		if d, ok := dst.(conversion.Convertible); ok {
+			return d.ConvertFrom(s)
+		}
+	case conversion.Convertible:
+		if d, ok := dst.(conversion.Hub); ok {
+			return s.ConvertTo(d)
+		}
+		if d, ok := dst.(conversion.Convertible); ok {
+			hub := &corev1alpha2.DrainerConfig{}
+			err := s.ConvertTo(hub)
+			if err != nil {
+				return err
+			}
+			return d.ConvertFrom(hub)
+		}
+	}
+
+	// Both objects are of the same version.
+	b, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, dst)
+}
+
+func newDrainerConfig(typeMeta metav1.TypeMeta) (runtime.Object, error) {
+	if typeMeta.Kind != "DrainerConfig" {
+		return nil, microerror.Maskf(unsupportedVersionError, "kind %q is not supported", typeMeta.Kind)
+	}
+
+	var obj runtime.Object
+	switch typeMeta.APIVersion {
+	case corev1alpha1.SchemeGroupVersion.String():
+		obj = &corev1alpha1.DrainerConfig{}
+	case corev1alpha2.SchemeGroupVersion.String():
+		obj = &corev1alpha2.DrainerConfig{}
+	default:
+		return nil, microerror.Maskf(unsupportedVersionError, "API version %q is not supported", typeMeta.APIVersion)
+	}
+	obj.GetObjectKind().SetGroupVersionKind(typeMeta.GroupVersionKind())
+
+	return obj, nil
+}
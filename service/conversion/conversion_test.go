@@ -0,0 +1,102 @@
+package conversion
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/giantswarm/k8sclient/v7/pkg/k8sclienttest"
+	"github.com/giantswarm/micrologger/microloggertest"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	corev1alpha1 "github.com/giantswarm/node-operator/api"
+	corev1alpha2 "github.com/giantswarm/node-operator/api/v1alpha2"
+)
+
+func Test_Convert(t *testing.T) {
+	testCases := []struct {
+		name              string
+		object            string
+		desiredAPIVersion string
+		errorMatcher      func(error) bool
+		expectedNode      string
+	}{
+		{
+			name:              "case 0: convert v1alpha1 to v1alpha2",
+			object:            `{"apiVersion":"core.giantswarm.io/v1alpha1","kind":"DrainerConfig","metadata":{"name":"node-1"},"spec":{"guest":{"cluster":{"api":{"endpoint":"api.abc12.example.com"},"id":"abc12"},"node":{"name":"node-1"}},"versionBundle":{"version":"0.2.0"}}}`,
+			desiredAPIVersion: corev1alpha2.SchemeGroupVersion.String(),
+			expectedNode:      "node-1",
+		},
+		{
+			name:              "case 1: convert v1alpha2 to v1alpha1",
+			object:            `{"apiVersion":"core.giantswarm.io/v1alpha2","kind":"DrainerConfig","metadata":{"name":"node-1"},"spec":{"node":{"name":"node-1"},"workloadCluster":{"api":{"endpoint":"api.abc12.example.com"},"id":"abc12"}}}`,
+			desiredAPIVersion: corev1alpha1.SchemeGroupVersion.String(),
+			expectedNode:      "node-1",
+		},
+		{
+			name:              "case 2: unsupported API version",
+			object:            `{"apiVersion":"core.giantswarm.io/v1alpha3","kind":"DrainerConfig","metadata":{"name":"node-1"}}`,
+			desiredAPIVersion: corev1alpha2.SchemeGroupVersion.String(),
+			errorMatcher:      IsUnsupportedVersion,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := New(Config{
+				K8sClient: k8sclienttest.NewEmpty(),
+				Logger:    microloggertest.New(),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			converted, err := s.Convert(context.Background(), []runtime.RawExtension{{Raw: []byte(tc.object)}}, tc.desiredAPIVersion)
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+			if tc.errorMatcher != nil {
+				return
+			}
+
+			var node string
+			{
+				var typeMeta struct {
+					APIVersion string `json:"apiVersion"`
+				}
+				err = json.Unmarshal(converted[0].Raw, &typeMeta)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if typeMeta.APIVersion != tc.desiredAPIVersion {
+					t.Fatalf("apiVersion == %q, want %q", typeMeta.APIVersion, tc.desiredAPIVersion)
+				}
+
+				switch tc.desiredAPIVersion {
+				case corev1alpha1.SchemeGroupVersion.String():
+					var dc corev1alpha1.DrainerConfig
+					err = json.Unmarshal(converted[0].Raw, &dc)
+					node = dc.Spec.Guest.Node.Name
+				default:
+					var dc corev1alpha2.DrainerConfig
+					err = json.Unmarshal(converted[0].Raw, &dc)
+					node = dc.Spec.Node.Name
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if node != tc.expectedNode {
+				t.Fatalf("node == %q, want %q", node, tc.expectedNode)
+			}
+		})
+	}
+}
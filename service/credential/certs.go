@@ -0,0 +1,28 @@
+package credential
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/tenantcluster/v6/pkg/tenantcluster"
+	"k8s.io/client-go/rest"
+
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+	"github.com/giantswarm/node-operator/service/controller/key"
+)
+
+// certsProvider accesses workload clusters with the certificates issued for
+// the operator. Looking them up times out if they do not exist, which
+// tenantcluster.IsTimeout asserts.
+type certsProvider struct {
+	tenantCluster tenantcluster.Interface
+}
+
+func (p *certsProvider) NewRestConfig(ctx context.Context, drainerConfig v1alpha2.DrainerConfig) (*rest.Config, error) {
+	restConfig, err := p.tenantCluster.NewRestConfig(ctx, key.ClusterIDFromDrainerConfig(drainerConfig), key.ClusterEndpointFromDrainerConfig(drainerConfig))
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return restConfig, nil
+}
@@ -0,0 +1,63 @@
+// Package credential provides the configs to access workload clusters with.
+// Workload clusters used to be accessed with certificates issued for the
+// operator only, so clusters without them could not be drained. The kind of
+// credentials is chosen per DrainerConfig in spec.workloadCluster.credentials.
+package credential
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/tenantcluster/v6/pkg/tenantcluster"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+	"github.com/giantswarm/node-operator/service/controller/key"
+)
+
+type Config struct {
+	Client        client.Client
+	TenantCluster tenantcluster.Interface
+}
+
+// Provider dispatches to the provider of the kind of credentials configured
+// for a DrainerConfig.
+type Provider struct {
+	providers map[string]Interface
+}
+
+func New(c Config) (*Provider, error) {
+	if c.Client == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Client must not be empty", c)
+	}
+	if c.TenantCluster == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.TenantCluster must not be empty", c)
+	}
+
+	p := &Provider{
+		providers: map[string]Interface{
+			v1alpha2.CredentialsTypeCerts:               &certsProvider{tenantCluster: c.TenantCluster},
+			v1alpha2.CredentialsTypeKubeconfig:          &kubeconfigProvider{client: c.Client},
+			v1alpha2.CredentialsTypeServiceAccountToken: &serviceAccountTokenProvider{client: c.Client},
+		},
+	}
+
+	return p, nil
+}
+
+func (p *Provider) NewRestConfig(ctx context.Context, drainerConfig v1alpha2.DrainerConfig) (*rest.Config, error) {
+	t := key.CredentialsTypeFromDrainerConfig(drainerConfig)
+
+	provider, ok := p.providers[t]
+	if !ok {
+		return nil, microerror.Maskf(unknownTypeError, "credentials type %#q", t)
+	}
+
+	restConfig, err := provider.NewRestConfig(ctx, drainerConfig)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return restConfig, nil
+}
@@ -0,0 +1,171 @@
+package credential
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+)
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: abc12
+  cluster:
+    server: https://api.abc12.example.com:6443
+contexts:
+- name: abc12
+  context:
+    cluster: abc12
+    user: abc12-admin
+current-context: abc12
+users:
+- name: abc12-admin
+  user:
+    token: kubeconfig-token
+`
+
+type testTenantCluster struct{}
+
+func (t testTenantCluster) NewRestConfig(ctx context.Context, clusterID, apiDomain string) (*rest.Config, error) {
+	return &rest.Config{Host: apiDomain, Username: clusterID}, nil
+}
+
+func Test_Provider_NewRestConfig(t *testing.T) {
+	newSecret := func(name string, data map[string]string) *corev1.Secret {
+		s := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "org-example"},
+			Data:       map[string][]byte{},
+		}
+		for k, v := range data {
+			s.Data[k] = []byte(v)
+		}
+		return s
+	}
+
+	testCases := []struct {
+		name          string
+		clusterAPI    bool
+		credentials   *v1alpha2.DrainerConfigSpecWorkloadClusterCredentials
+		existing      []client.Object
+		expectedHost  string
+		expectedToken string
+		errorMatcher  func(error) bool
+	}{
+		{
+			name:         "case 0: certificates are used by default",
+			expectedHost: "api.abc12.example.com",
+		},
+		{
+			name:          "case 1: Cluster API clusters use their kubeconfig Secret by default",
+			clusterAPI:    true,
+			existing:      []client.Object{newSecret("abc12-kubeconfig", map[string]string{"value": testKubeconfig})},
+			expectedHost:  "https://api.abc12.example.com:6443",
+			expectedToken: "kubeconfig-token",
+		},
+		{
+			name: "case 2: kubeconfig is read from the configured Secret and key",
+			credentials: &v1alpha2.DrainerConfigSpecWorkloadClusterCredentials{
+				Type:   v1alpha2.CredentialsTypeKubeconfig,
+				Secret: &v1alpha2.DrainerConfigSpecWorkloadClusterCredentialsSecret{Name: "drain-access", Key: "kubeconfig"},
+			},
+			existing:      []client.Object{newSecret("drain-access", map[string]string{"kubeconfig": testKubeconfig})},
+			expectedHost:  "https://api.abc12.example.com:6443",
+			expectedToken: "kubeconfig-token",
+		},
+		{
+			name: "case 3: service account token is used with the endpoint",
+			credentials: &v1alpha2.DrainerConfigSpecWorkloadClusterCredentials{
+				Type:   v1alpha2.CredentialsTypeServiceAccountToken,
+				Secret: &v1alpha2.DrainerConfigSpecWorkloadClusterCredentialsSecret{Name: "node-operator-token"},
+			},
+			existing:      []client.Object{newSecret("node-operator-token", map[string]string{"token": "sa-token", "ca.crt": "ca"})},
+			expectedHost:  "api.abc12.example.com",
+			expectedToken: "sa-token",
+		},
+		{
+			name:         "case 4: missing Secret",
+			clusterAPI:   true,
+			errorMatcher: IsSecretNotFound,
+		},
+		{
+			name: "case 5: Secret without token",
+			credentials: &v1alpha2.DrainerConfigSpecWorkloadClusterCredentials{
+				Type:   v1alpha2.CredentialsTypeServiceAccountToken,
+				Secret: &v1alpha2.DrainerConfigSpecWorkloadClusterCredentialsSecret{Name: "node-operator-token"},
+			},
+			existing:     []client.Object{newSecret("node-operator-token", map[string]string{"ca.crt": "ca"})},
+			errorMatcher: IsSecretNotFound,
+		},
+		{
+			name: "case 6: unknown type",
+			credentials: &v1alpha2.DrainerConfigSpecWorkloadClusterCredentials{
+				Type: "Password",
+			},
+			errorMatcher: IsUnknownType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			drainerConfig := v1alpha2.DrainerConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "ip-10-1-2-3", Namespace: "org-example"},
+				Spec: v1alpha2.DrainerConfigSpec{
+					WorkloadCluster: v1alpha2.DrainerConfigSpecWorkloadCluster{
+						API:         v1alpha2.DrainerConfigSpecWorkloadClusterAPI{Endpoint: "api.abc12.example.com"},
+						Credentials: tc.credentials,
+						ID:          "abc12",
+					},
+				},
+			}
+			if tc.clusterAPI {
+				drainerConfig.Spec.ClusterAPI = &v1alpha2.DrainerConfigSpecClusterAPI{}
+			}
+
+			scheme := runtime.NewScheme()
+			err := corev1.AddToScheme(scheme)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			p, err := New(Config{
+				Client:        fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.existing...).Build(),
+				TenantCluster: testTenantCluster{},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			restConfig, err := p.NewRestConfig(context.Background(), drainerConfig)
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+
+			if tc.errorMatcher != nil {
+				return
+			}
+
+			if restConfig.Host != tc.expectedHost {
+				t.Fatalf("Host == %#q, want %#q", restConfig.Host, tc.expectedHost)
+			}
+			if restConfig.BearerToken != tc.expectedToken {
+				t.Fatalf("BearerToken == %#q, want %#q", restConfig.BearerToken, tc.expectedToken)
+			}
+		})
+	}
+}
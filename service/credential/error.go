@@ -0,0 +1,30 @@
+package credential
+
+import "github.com/giantswarm/microerror"
+
+var invalidConfigError = &microerror.Error{
+	Kind: "invalidConfigError",
+}
+
+// IsInvalidConfig asserts invalidConfigError.
+func IsInvalidConfig(err error) bool {
+	return microerror.Cause(err) == invalidConfigError
+}
+
+var secretNotFoundError = &microerror.Error{
+	Kind: "secretNotFoundError",
+}
+
+// IsSecretNotFound asserts secretNotFoundError.
+func IsSecretNotFound(err error) bool {
+	return microerror.Cause(err) == secretNotFoundError
+}
+
+var unknownTypeError = &microerror.Error{
+	Kind: "unknownTypeError",
+}
+
+// IsUnknownType asserts unknownTypeError.
+func IsUnknownType(err error) bool {
+	return microerror.Cause(err) == unknownTypeError
+}
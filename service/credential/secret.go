@@ -0,0 +1,90 @@
+package credential
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+	"github.com/giantswarm/node-operator/service/controller/key"
+)
+
+// kubeconfigProvider accesses workload clusters with a kubeconfig stored in a
+// Secret, by default the one Cluster API creates for every Cluster.
+type kubeconfigProvider struct {
+	client client.Client
+}
+
+func (p *kubeconfigProvider) NewRestConfig(ctx context.Context, drainerConfig v1alpha2.DrainerConfig) (*rest.Config, error) {
+	secretKey := key.CredentialsSecretKeyFromDrainerConfig(drainerConfig)
+
+	data, err := secretData(ctx, p.client, drainerConfig, secretKey)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(data[secretKey])
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return restConfig, nil
+}
+
+// serviceAccountTokenProvider accesses workload clusters at the endpoint of
+// the DrainerConfig with the token of a service account of the workload
+// cluster, stored in a Secret like the ones of type
+// kubernetes.io/service-account-token.
+type serviceAccountTokenProvider struct {
+	client client.Client
+}
+
+func (p *serviceAccountTokenProvider) NewRestConfig(ctx context.Context, drainerConfig v1alpha2.DrainerConfig) (*rest.Config, error) {
+	data, err := secretData(ctx, p.client, drainerConfig, corev1.ServiceAccountTokenKey, corev1.ServiceAccountRootCAKey)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	restConfig := &rest.Config{
+		Host:        key.ClusterEndpointFromDrainerConfig(drainerConfig),
+		BearerToken: string(data[corev1.ServiceAccountTokenKey]),
+		TLSClientConfig: rest.TLSClientConfig{
+			CAData: data[corev1.ServiceAccountRootCAKey],
+		},
+	}
+
+	return restConfig, nil
+}
+
+// secretData returns the data of the credentials Secret of the given
+// DrainerConfig. It fails with secretNotFoundError if the Secret or one of the
+// given keys does not exist, e.g. because the workload cluster is still being
+// created.
+func secretData(ctx context.Context, c client.Client, drainerConfig v1alpha2.DrainerConfig, keys ...string) (map[string][]byte, error) {
+	name := key.CredentialsSecretNameFromDrainerConfig(drainerConfig)
+	if name == "" {
+		return nil, microerror.Maskf(secretNotFoundError, "no secret configured")
+	}
+
+	secret := &corev1.Secret{}
+	err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: drainerConfig.Namespace}, secret)
+	if apierrors.IsNotFound(err) {
+		return nil, microerror.Maskf(secretNotFoundError, "secret %s/%s does not exist", drainerConfig.Namespace, name)
+	} else if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	for _, k := range keys {
+		if len(secret.Data[k]) == 0 {
+			return nil, microerror.Maskf(secretNotFoundError, "secret %s/%s has no %#q key", drainerConfig.Namespace, name, k)
+		}
+	}
+
+	return secret.Data, nil
+}
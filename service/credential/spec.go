@@ -0,0 +1,15 @@
+package credential
+
+import (
+	"context"
+
+	"k8s.io/client-go/rest"
+
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+)
+
+type Interface interface {
+	// NewRestConfig returns the config to access the workload cluster of the
+	// given DrainerConfig with.
+	NewRestConfig(ctx context.Context, drainerConfig v1alpha2.DrainerConfig) (*rest.Config, error)
+}
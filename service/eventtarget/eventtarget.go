@@ -0,0 +1,153 @@
+// Package eventtarget resolves the object events about a drain are recorded
+// for. Events used to be recorded for the AWSCluster of the workload cluster
+// only, which does not exist for other providers, so the target is chosen by
+// configuration or by what exists in the management cluster.
+package eventtarget
+
+import (
+	"context"
+
+	infrastructurev1alpha3 "github.com/giantswarm/apiextensions/v6/pkg/apis/infrastructure/v1alpha3"
+	"github.com/giantswarm/microerror"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	pkgruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+	"github.com/giantswarm/node-operator/service/controller/key"
+)
+
+const (
+	// KindAuto means events are recorded for the first of the AWSCluster,
+	// the Cluster API Cluster and the DrainerConfig which exists.
+	KindAuto = "Auto"
+	// KindAWSCluster means events are recorded for the AWSCluster named
+	// after the workload cluster ID.
+	KindAWSCluster = "AWSCluster"
+	// KindCluster means events are recorded for the Cluster API Cluster
+	// named after the workload cluster ID.
+	KindCluster = "Cluster"
+	// KindDrainerConfig means events are recorded for the DrainerConfig
+	// itself.
+	KindDrainerConfig = "DrainerConfig"
+	// KindNone means events are only recorded for the DrainerConfig and its
+	// node.
+	KindNone = "None"
+)
+
+// clusterGroupVersionKind is the kind of Cluster API Clusters.
+var clusterGroupVersionKind = key.ClusterAPIGroupVersion.WithKind("Cluster")
+
+type Config struct {
+	Client client.Client
+
+	// Kind is the kind of object events are recorded for. See the Kind
+	// constants. Defaults to Auto.
+	Kind string
+}
+
+type Resolver struct {
+	client client.Client
+
+	kind string
+}
+
+func New(c Config) (*Resolver, error) {
+	if c.Client == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Client must not be empty", c)
+	}
+
+	if c.Kind == "" {
+		c.Kind = KindAuto
+	}
+	switch c.Kind {
+	case KindAuto, KindAWSCluster, KindCluster, KindDrainerConfig, KindNone:
+	default:
+		return nil, microerror.Maskf(invalidConfigError, "%T.Kind must be one of %s, %s, %s, %s or %s, got %#q", c, KindAuto, KindAWSCluster, KindCluster, KindDrainerConfig, KindNone, c.Kind)
+	}
+
+	r := &Resolver{
+		client: c.Client,
+
+		kind: c.Kind,
+	}
+
+	return r, nil
+}
+
+// Resolve returns the object events about the drain of the given
+// DrainerConfig are recorded for. The DrainerConfig itself is returned when
+// the configured AWSCluster or Cluster does not exist, so that drains never
+// depend on infrastructure objects of a particular provider.
+func (r *Resolver) Resolve(ctx context.Context, drainerConfig v1alpha2.DrainerConfig) (pkgruntime.Object, error) {
+	var candidates []func(context.Context, v1alpha2.DrainerConfig) (pkgruntime.Object, error)
+	switch r.kind {
+	case KindNone:
+		return nil, nil
+	case KindDrainerConfig:
+		return &drainerConfig, nil
+	case KindAWSCluster:
+		candidates = append(candidates, r.awsCluster)
+	case KindCluster:
+		candidates = append(candidates, r.cluster)
+	default:
+		candidates = append(candidates, r.awsCluster, r.cluster)
+	}
+
+	for _, get := range candidates {
+		obj, err := get(ctx, drainerConfig)
+		if isNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		return obj, nil
+	}
+
+	return &drainerConfig, nil
+}
+
+func (r *Resolver) awsCluster(ctx context.Context, drainerConfig v1alpha2.DrainerConfig) (pkgruntime.Object, error) {
+	awsCluster := &infrastructurev1alpha3.AWSCluster{}
+	err := r.client.Get(ctx, clusterName(drainerConfig), awsCluster)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return awsCluster, nil
+}
+
+func (r *Resolver) cluster(ctx context.Context, drainerConfig v1alpha2.DrainerConfig) (pkgruntime.Object, error) {
+	cluster := &unstructured.Unstructured{}
+	cluster.SetGroupVersionKind(clusterGroupVersionKind)
+
+	err := r.client.Get(ctx, clusterName(drainerConfig), cluster)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return cluster, nil
+}
+
+// clusterName returns the name of the objects of the workload cluster of the
+// given DrainerConfig, which live next to it.
+func clusterName(drainerConfig v1alpha2.DrainerConfig) types.NamespacedName {
+	return types.NamespacedName{Name: key.ClusterIDFromDrainerConfig(drainerConfig), Namespace: drainerConfig.Namespace}
+}
+
+// isNotFound checks whether the given error tells that the object, or its
+// kind, does not exist in the management cluster, e.g. because the provider
+// does not use AWSClusters or Cluster API is not installed.
+func isNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	cause := microerror.Cause(err)
+
+	return apierrors.IsNotFound(cause) || meta.IsNoMatchError(cause) || pkgruntime.IsNotRegisteredError(cause)
+}
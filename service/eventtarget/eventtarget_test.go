@@ -0,0 +1,108 @@
+package eventtarget
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	infrastructurev1alpha3 "github.com/giantswarm/apiextensions/v6/pkg/apis/infrastructure/v1alpha3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+)
+
+func Test_Resolver_Resolve(t *testing.T) {
+	drainerConfig := v1alpha2.DrainerConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "ip-10-1-2-3", Namespace: "org-example"},
+		Spec: v1alpha2.DrainerConfigSpec{
+			WorkloadCluster: v1alpha2.DrainerConfigSpecWorkloadCluster{ID: "abc12"},
+		},
+	}
+
+	awsCluster := &infrastructurev1alpha3.AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "abc12", Namespace: "org-example"},
+	}
+
+	cluster := &unstructured.Unstructured{}
+	cluster.SetGroupVersionKind(clusterGroupVersionKind)
+	cluster.SetName("abc12")
+	cluster.SetNamespace("org-example")
+
+	testCases := []struct {
+		name         string
+		kind         string
+		existing     []client.Object
+		expectedType string
+	}{
+		{
+			name:         "case 0: auto prefers the AWSCluster",
+			kind:         KindAuto,
+			existing:     []client.Object{awsCluster, cluster},
+			expectedType: "*v1alpha3.AWSCluster",
+		},
+		{
+			name:         "case 1: auto falls back to the Cluster",
+			kind:         KindAuto,
+			existing:     []client.Object{cluster},
+			expectedType: "*unstructured.Unstructured",
+		},
+		{
+			name:         "case 2: auto falls back to the DrainerConfig",
+			kind:         KindAuto,
+			expectedType: "*v1alpha2.DrainerConfig",
+		},
+		{
+			name:         "case 3: missing AWSCluster falls back to the DrainerConfig",
+			kind:         KindAWSCluster,
+			existing:     []client.Object{cluster},
+			expectedType: "*v1alpha2.DrainerConfig",
+		},
+		{
+			name:         "case 4: DrainerConfig is used even if the AWSCluster exists",
+			kind:         KindDrainerConfig,
+			existing:     []client.Object{awsCluster},
+			expectedType: "*v1alpha2.DrainerConfig",
+		},
+		{
+			name:         "case 5: no events are recorded",
+			kind:         KindNone,
+			existing:     []client.Object{awsCluster},
+			expectedType: "<nil>",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			err := v1alpha2.AddToScheme(scheme)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = infrastructurev1alpha3.AddToScheme(scheme)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r, err := New(Config{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.existing...).Build(),
+				Kind:   tc.kind,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			obj, err := r.Resolve(context.Background(), drainerConfig)
+			if err != nil {
+				t.Fatalf("expected nil, got %#v", err)
+			}
+
+			if fmt.Sprintf("%T", obj) != tc.expectedType {
+				t.Fatalf("expected %s, got %T", tc.expectedType, obj)
+			}
+		})
+	}
+}
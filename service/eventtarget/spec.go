@@ -0,0 +1,16 @@
+package eventtarget
+
+import (
+	"context"
+
+	pkgruntime "k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/giantswarm/node-operator/api/v1alpha2"
+)
+
+type Interface interface {
+	// Resolve returns the object events about the drain of the given
+	// DrainerConfig are recorded for. It returns nil if no events are to be
+	// recorded.
+	Resolve(ctx context.Context, drainerConfig v1alpha2.DrainerConfig) (pkgruntime.Object, error)
+}
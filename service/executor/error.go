@@ -0,0 +1,39 @@
+package executor
+
+import "github.com/giantswarm/microerror"
+
+var canceledError = &microerror.Error{
+	Kind: "canceledError",
+}
+
+// IsCanceled asserts canceledError.
+func IsCanceled(err error) bool {
+	return microerror.Cause(err) == canceledError
+}
+
+var invalidConfigError = &microerror.Error{
+	Kind: "invalidConfigError",
+}
+
+// IsInvalidConfig asserts invalidConfigError.
+func IsInvalidConfig(err error) bool {
+	return microerror.Cause(err) == invalidConfigError
+}
+
+var queueFullError = &microerror.Error{
+	Kind: "queueFullError",
+}
+
+// IsQueueFull asserts queueFullError.
+func IsQueueFull(err error) bool {
+	return microerror.Cause(err) == queueFullError
+}
+
+var stoppedError = &microerror.Error{
+	Kind: "stoppedError",
+}
+
+// IsStopped asserts stoppedError.
+func IsStopped(err error) bool {
+	return microerror.Cause(err) == stoppedError
+}
@@ -0,0 +1,303 @@
+// Package executor runs long running tasks, like node drains, on a bounded
+// pool of workers. Tasks wait in a queue until a worker is free and the
+// workload cluster they belong to is below its concurrency limit, so that
+// many drains requested at once, e.g. during a cluster upgrade, do not
+// overwhelm the management cluster or a single workload cluster API.
+package executor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+)
+
+// State is the state of a task known to the executor.
+type State string
+
+const (
+	// StateUnknown means the executor does not know about the task.
+	StateUnknown State = ""
+	// StateQueued means the task waits for a worker.
+	StateQueued State = "Queued"
+	// StateRunning means a worker runs the task.
+	StateRunning State = "Running"
+	// StateDone means the task finished. Its result is kept until it gets
+	// forgotten.
+	StateDone State = "Done"
+)
+
+type Config struct {
+	Logger micrologger.Logger
+
+	// ClusterWorkers is the maximum number of tasks running at the same time
+	// for a single workload cluster.
+	ClusterWorkers int
+	// QueueSize is the maximum number of tasks waiting for a worker.
+	QueueSize int
+	// Workers is the maximum number of tasks running at the same time.
+	Workers int
+}
+
+// Task is a unit of work run by the executor.
+type Task struct {
+	// Cluster is the ID of the workload cluster the task belongs to.
+	Cluster string
+	// ID identifies the task. The executor knows about at most one task per
+	// ID at a time.
+	ID string
+	// Run does the work. The given context is canceled when the task gets
+	// canceled or the executor stops.
+	Run func(ctx context.Context) error
+	// OnDone is called with the result of Run once the task finished, e.g. to
+	// requeue the object the task belongs to. It is optional.
+	OnDone func(ctx context.Context, err error)
+}
+
+type Executor struct {
+	logger micrologger.Logger
+
+	clusterWorkers int
+	queueSize      int
+	workers        int
+
+	bootOnce sync.Once
+	cond     *sync.Cond
+	mutex    sync.Mutex
+	queue    []*task
+	running  map[string]int
+	stopped  bool
+	tasks    map[string]*task
+}
+
+type task struct {
+	Task
+
+	// ctx carries the values of the context the task got submitted with, but
+	// not its cancellation.
+	ctx    context.Context
+	cancel context.CancelFunc
+	err    error
+	runCtx context.Context
+	state  State
+}
+
+func New(config Config) (*Executor, error) {
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.ClusterWorkers <= 0 {
+		return nil, microerror.Maskf(invalidConfigError, "%T.ClusterWorkers must be greater than 0", config)
+	}
+	if config.QueueSize <= 0 {
+		return nil, microerror.Maskf(invalidConfigError, "%T.QueueSize must be greater than 0", config)
+	}
+	if config.Workers <= 0 {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Workers must be greater than 0", config)
+	}
+
+	e := &Executor{
+		logger: config.Logger,
+
+		clusterWorkers: config.ClusterWorkers,
+		queueSize:      config.QueueSize,
+		workers:        config.Workers,
+
+		running: map[string]int{},
+		tasks:   map[string]*task{},
+	}
+	e.cond = sync.NewCond(&e.mutex)
+
+	return e, nil
+}
+
+// Boot starts the workers. They stop and cancel all tasks once the given
+// context is done.
+func (e *Executor) Boot(ctx context.Context) {
+	e.bootOnce.Do(func() {
+		for i := 0; i < e.workers; i++ {
+			go e.work()
+		}
+
+		go func() {
+			<-ctx.Done()
+			e.stop()
+		}()
+	})
+}
+
+// Cancel cancels the task with the given ID. Queued tasks finish right away
+// with an error asserted by IsCanceled, running tasks get their context
+// canceled.
+func (e *Executor) Cancel(id string) {
+	e.mutex.Lock()
+	t, ok := e.tasks[id]
+	if !ok {
+		e.mutex.Unlock()
+		return
+	}
+
+	switch t.state {
+	case StateQueued:
+		e.removeFromQueue(t)
+		t.cancel()
+		t.err = microerror.Maskf(canceledError, "task %#q got canceled before it started", id)
+		t.state = StateDone
+		e.mutex.Unlock()
+
+		if t.OnDone != nil {
+			t.OnDone(t.ctx, t.err)
+		}
+	case StateRunning:
+		t.cancel()
+		e.mutex.Unlock()
+	default:
+		e.mutex.Unlock()
+	}
+}
+
+// Forget drops the result of the finished task with the given ID, so that a
+// new task with the same ID can be submitted.
+func (e *Executor) Forget(id string) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	t, ok := e.tasks[id]
+	if ok && t.state == StateDone {
+		delete(e.tasks, id)
+	}
+}
+
+// Result returns the state of the task with the given ID and, if it is done,
+// the error it finished with.
+func (e *Executor) Result(id string) (State, error) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	t, ok := e.tasks[id]
+	if !ok {
+		return StateUnknown, nil
+	}
+
+	return t.state, t.err
+}
+
+// Submit queues the given task. Submitting a task whose ID is known already
+// is a no-op. It returns an error asserted by IsQueueFull if there are too
+// many tasks waiting for a worker.
+func (e *Executor) Submit(ctx context.Context, t Task) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if e.stopped {
+		return microerror.Maskf(stoppedError, "cannot submit task %#q", t.ID)
+	}
+	if _, ok := e.tasks[t.ID]; ok {
+		return nil
+	}
+	if len(e.queue) >= e.queueSize {
+		return microerror.Maskf(queueFullError, "%d tasks are waiting for a worker", len(e.queue))
+	}
+
+	parent := context.WithoutCancel(ctx)
+	runCtx, cancel := context.WithCancel(parent)
+
+	queued := &task{
+		Task: t,
+
+		ctx:    parent,
+		cancel: cancel,
+		runCtx: runCtx,
+		state:  StateQueued,
+	}
+
+	e.queue = append(e.queue, queued)
+	e.tasks[t.ID] = queued
+	e.cond.Broadcast()
+
+	return nil
+}
+
+// finish records the result of the given task and frees its worker.
+func (e *Executor) finish(t *task, err error) {
+	e.mutex.Lock()
+	e.running[t.Cluster]--
+	if e.running[t.Cluster] <= 0 {
+		delete(e.running, t.Cluster)
+	}
+	t.cancel()
+	t.err = err
+	t.state = StateDone
+	e.cond.Broadcast()
+	e.mutex.Unlock()
+
+	if t.OnDone != nil {
+		t.OnDone(t.ctx, err)
+	}
+}
+
+// next blocks until there is a queued task whose workload cluster is below
+// its concurrency limit and returns it. It returns nil once the executor got
+// stopped.
+func (e *Executor) next() *task {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	for {
+		if e.stopped {
+			return nil
+		}
+
+		for _, t := range e.queue {
+			if e.running[t.Cluster] >= e.clusterWorkers {
+				continue
+			}
+
+			e.removeFromQueue(t)
+			e.running[t.Cluster]++
+			t.state = StateRunning
+
+			return t
+		}
+
+		e.cond.Wait()
+	}
+}
+
+// removeFromQueue must be called with the mutex held.
+func (e *Executor) removeFromQueue(t *task) {
+	for i := range e.queue {
+		if e.queue[i] == t {
+			e.queue = append(e.queue[:i], e.queue[i+1:]...)
+			return
+		}
+	}
+}
+
+func (e *Executor) stop() {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.stopped = true
+	for _, t := range e.tasks {
+		t.cancel()
+	}
+	e.cond.Broadcast()
+}
+
+func (e *Executor) work() {
+	for {
+		t := e.next()
+		if t == nil {
+			return
+		}
+
+		e.logger.LogCtx(t.ctx, "level", "debug", "message", fmt.Sprintf("running task %#q of cluster %#q", t.ID, t.Cluster))
+
+		err := t.Run(t.runCtx)
+
+		e.finish(t, err)
+	}
+}
@@ -0,0 +1,179 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/giantswarm/micrologger/microloggertest"
+)
+
+func Test_Executor_ClusterWorkers(t *testing.T) {
+	e := newTestExecutor(t, Config{
+		ClusterWorkers: 1,
+		QueueSize:      10,
+		Workers:        2,
+	})
+
+	release := make(chan struct{})
+	started := make(chan string, 3)
+	done := make(chan string, 3)
+
+	for _, id := range []string{"a-1", "a-2", "b-1"} {
+		id := id
+		cluster := id[:1]
+
+		err := e.Submit(context.Background(), Task{
+			Cluster: cluster,
+			ID:      id,
+			Run: func(ctx context.Context) error {
+				started <- id
+				<-release
+				return nil
+			},
+			OnDone: func(ctx context.Context, err error) {
+				done <- id
+			},
+		})
+		if err != nil {
+			t.Fatalf("Submit() returned error %#v", err)
+		}
+	}
+
+	// One task of each cluster runs, the second task of cluster a waits for
+	// the first one although a worker would be free.
+	for i := 0; i < 2; i++ {
+		<-started
+	}
+	select {
+	case id := <-started:
+		t.Fatalf("task %q started while its cluster was at its limit", id)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	state, _ := e.Result("a-2")
+	if state != StateQueued {
+		t.Fatalf("state == %q, expected %q", state, StateQueued)
+	}
+
+	close(release)
+	for i := 0; i < 3; i++ {
+		<-done
+	}
+
+	state, err := e.Result("a-2")
+	if state != StateDone {
+		t.Fatalf("state == %q, expected %q", state, StateDone)
+	}
+	if err != nil {
+		t.Fatalf("err == %#v, expected nil", err)
+	}
+
+	e.Forget("a-2")
+	state, _ = e.Result("a-2")
+	if state != StateUnknown {
+		t.Fatalf("state == %q, expected %q", state, StateUnknown)
+	}
+}
+
+func Test_Executor_QueueFull(t *testing.T) {
+	// The executor is not booted, so that all tasks stay queued.
+	e, err := New(Config{
+		Logger: microloggertest.New(),
+
+		ClusterWorkers: 1,
+		QueueSize:      1,
+		Workers:        1,
+	})
+	if err != nil {
+		t.Fatalf("New() returned error %#v", err)
+	}
+
+	run := func(ctx context.Context) error { return nil }
+
+	err = e.Submit(context.Background(), Task{Cluster: "a", ID: "a-1", Run: run})
+	if err != nil {
+		t.Fatalf("Submit() returned error %#v", err)
+	}
+	// Submitting the same task again is a no-op.
+	err = e.Submit(context.Background(), Task{Cluster: "a", ID: "a-1", Run: run})
+	if err != nil {
+		t.Fatalf("Submit() returned error %#v", err)
+	}
+
+	err = e.Submit(context.Background(), Task{Cluster: "a", ID: "a-2", Run: run})
+	if !IsQueueFull(err) {
+		t.Fatalf("err == %#v, expected queueFullError", err)
+	}
+}
+
+func Test_Executor_Cancel(t *testing.T) {
+	e := newTestExecutor(t, Config{
+		ClusterWorkers: 1,
+		QueueSize:      10,
+		Workers:        1,
+	})
+
+	started := make(chan struct{})
+	done := make(chan error, 2)
+
+	err := e.Submit(context.Background(), Task{
+		Cluster: "a",
+		ID:      "a-1",
+		Run: func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			return ctx.Err()
+		},
+		OnDone: func(ctx context.Context, err error) {
+			done <- err
+		},
+	})
+	if err != nil {
+		t.Fatalf("Submit() returned error %#v", err)
+	}
+	err = e.Submit(context.Background(), Task{
+		Cluster: "a",
+		ID:      "a-2",
+		Run: func(ctx context.Context) error {
+			t.Errorf("canceled task must not run")
+			return nil
+		},
+		OnDone: func(ctx context.Context, err error) {
+			done <- err
+		},
+	})
+	if err != nil {
+		t.Fatalf("Submit() returned error %#v", err)
+	}
+
+	<-started
+
+	e.Cancel("a-2")
+	err = <-done
+	if !IsCanceled(err) {
+		t.Fatalf("err == %#v, expected canceledError", err)
+	}
+
+	e.Cancel("a-1")
+	err = <-done
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err == %#v, expected context.Canceled", err)
+	}
+}
+
+func newTestExecutor(t *testing.T, c Config) *Executor {
+	c.Logger = microloggertest.New()
+
+	e, err := New(c)
+	if err != nil {
+		t.Fatalf("New() returned error %#v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	e.Boot(ctx)
+
+	return e
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/giantswarm/k8sclient/v7/pkg/k8sclienttest"
 	corev1 "k8s.io/api/core/v1"
 	pkgruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/record"
 )
@@ -19,6 +20,8 @@ type Config struct {
 
 type Recorder struct {
 	record.EventRecorder
+
+	component string
 }
 
 // New creates an event recorder to send custom events to Kubernetes to be recorded for targeted Kubernetes objects.
@@ -33,18 +36,37 @@ func New(c Config) Interface {
 		)
 	}
 	return &Recorder{
-		eventBroadcaster.NewRecorder(c.K8sClient.Scheme(), corev1.EventSource{Component: c.Component}),
+		EventRecorder: eventBroadcaster.NewRecorder(c.K8sClient.Scheme(), corev1.EventSource{Component: c.Component}),
+
+		component: c.Component,
 	}
 }
 
-// Warn writes warning events like status of failed draining pods.
+// Warn writes warning events like status of failed draining pods. Nothing is
+// written for a nil object, which means events are not recorded.
 func (r *Recorder) Warn(ctx context.Context, obj pkgruntime.Object, reason, message string) {
-	r.Event(obj, corev1.EventTypeWarning, reason, upper(message))
+	r.record(ctx, obj, corev1.EventTypeWarning, reason, message)
 }
 
-// Info writes informational events.
+// Info writes informational events. Nothing is written for a nil object.
 func (r *Recorder) Info(ctx context.Context, obj pkgruntime.Object, reason, message string) {
-	r.Event(obj, corev1.EventTypeNormal, reason, upper(message))
+	r.record(ctx, obj, corev1.EventTypeNormal, reason, message)
+}
+
+// WorkloadCluster returns a recorder writing events to the workload cluster
+// the given client belongs to.
+func (r *Recorder) WorkloadCluster(k8sClient kubernetes.Interface) Interface {
+	return newWorkloadClusterRecorder(r.component, k8sClient)
+}
+
+func (r *Recorder) record(ctx context.Context, obj pkgruntime.Object, eventType, reason, message string) {
+	if obj != nil {
+		r.Event(obj, eventType, reason, upper(message))
+	}
+
+	fanOut(ctx, eventType, reason, message, func(obj pkgruntime.Object) {
+		r.Event(obj, eventType, reason, upper(message))
+	})
 }
 
 // upper is a helper function to uppercase first letter of the event message
@@ -4,9 +4,17 @@ import (
 	"context"
 
 	pkgruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
 )
 
+// Interface records events for Kubernetes objects. Info and Warn record the
+// event for the given object as well as for the targets added to the context
+// with WithTargets, so that a single call fans out to every object users look
+// at.
 type Interface interface {
 	Info(ctx context.Context, obj pkgruntime.Object, reason, message string)
 	Warn(ctx context.Context, obj pkgruntime.Object, reason, message string)
+	// WorkloadCluster returns a recorder writing events to the workload
+	// cluster the given client belongs to, e.g. for its nodes.
+	WorkloadCluster(k8sClient kubernetes.Interface) Interface
 }
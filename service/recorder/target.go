@@ -0,0 +1,63 @@
+package recorder
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	pkgruntime "k8s.io/apimachinery/pkg/runtime"
+)
+
+type targetsKey struct{}
+
+// Target is an object events are recorded for in addition to the object given
+// to Info and Warn, e.g. the DrainerConfig a drain belongs to.
+type Target struct {
+	Object pkgruntime.Object
+	// Recorder records the events for Object, e.g. one returned by
+	// WorkloadCluster for objects of a workload cluster. The recorder Info
+	// and Warn are called on is used if it is nil.
+	Recorder Interface
+}
+
+// WithTargets returns a copy of the given context making recorders record
+// every event for the given targets too.
+func WithTargets(ctx context.Context, targets ...Target) context.Context {
+	var all []Target
+	all = append(all, targetsFromContext(ctx)...)
+	all = append(all, targets...)
+
+	return context.WithValue(ctx, targetsKey{}, all)
+}
+
+func targetsFromContext(ctx context.Context) []Target {
+	targets, _ := ctx.Value(targetsKey{}).([]Target)
+	return targets
+}
+
+// withoutTargets returns a copy of the given context without targets, so that
+// the recorders of targets do not fan out the event again.
+func withoutTargets(ctx context.Context) context.Context {
+	return context.WithValue(ctx, targetsKey{}, []Target(nil))
+}
+
+// fanOut records the event for the targets of the given context, using the
+// given record function for targets without their own recorder.
+func fanOut(ctx context.Context, eventType, reason, message string, record func(obj pkgruntime.Object)) {
+	targets := targetsFromContext(ctx)
+	if len(targets) == 0 {
+		return
+	}
+
+	ctx = withoutTargets(ctx)
+	for _, t := range targets {
+		switch {
+		case t.Object == nil:
+		case t.Recorder == nil:
+			record(t.Object)
+		case eventType == corev1.EventTypeWarning:
+			t.Recorder.Warn(ctx, t.Object, reason, message)
+		default:
+			t.Recorder.Info(ctx, t.Object, reason, message)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package recorder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/reference"
+)
+
+// workloadClusterRecorder writes events to a workload cluster. Clients of
+// workload clusters are short lived, so events are written right away instead
+// of running an event broadcaster in the background for every client. Writing
+// events is best effort and never fails the caller.
+type workloadClusterRecorder struct {
+	component string
+	k8sClient kubernetes.Interface
+}
+
+func newWorkloadClusterRecorder(component string, k8sClient kubernetes.Interface) *workloadClusterRecorder {
+	return &workloadClusterRecorder{
+		component: component,
+		k8sClient: k8sClient,
+	}
+}
+
+func (r *workloadClusterRecorder) Warn(ctx context.Context, obj pkgruntime.Object, reason, message string) {
+	r.record(ctx, obj, corev1.EventTypeWarning, reason, message)
+}
+
+func (r *workloadClusterRecorder) Info(ctx context.Context, obj pkgruntime.Object, reason, message string) {
+	r.record(ctx, obj, corev1.EventTypeNormal, reason, message)
+}
+
+func (r *workloadClusterRecorder) WorkloadCluster(k8sClient kubernetes.Interface) Interface {
+	return newWorkloadClusterRecorder(r.component, k8sClient)
+}
+
+func (r *workloadClusterRecorder) record(ctx context.Context, obj pkgruntime.Object, eventType, reason, message string) {
+	if obj != nil {
+		r.write(ctx, obj, eventType, reason, message)
+	}
+
+	fanOut(ctx, eventType, reason, message, func(obj pkgruntime.Object) {
+		r.write(ctx, obj, eventType, reason, message)
+	})
+}
+
+func (r *workloadClusterRecorder) write(ctx context.Context, obj pkgruntime.Object, eventType, reason, message string) {
+	event, err := newEvent(obj, r.component, eventType, reason, upper(message), metav1.Now())
+	if err != nil {
+		return
+	}
+
+	_, _ = r.k8sClient.CoreV1().Events(event.Namespace).Create(ctx, event, metav1.CreateOptions{})
+}
+
+// newEvent returns the event for the given object the way the event
+// broadcaster of client-go creates it. Events of cluster scoped objects, like
+// nodes, are created in the default namespace.
+func newEvent(obj pkgruntime.Object, component, eventType, reason, message string, now metav1.Time) (*corev1.Event, error) {
+	ref, err := reference.GetReference(scheme.Scheme, obj)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = metav1.NamespaceDefault
+	}
+
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%v.%x", ref.Name, now.UnixNano()),
+			Namespace: namespace,
+		},
+		InvolvedObject: *ref,
+		Reason:         reason,
+		Message:        message,
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+		Type:           eventType,
+		Source: corev1.EventSource{
+			Component: component,
+		},
+	}
+
+	return event, nil
+}
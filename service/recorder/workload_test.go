@@ -0,0 +1,67 @@
+package recorder
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_workloadClusterRecorder_fanOut(t *testing.T) {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "ip-10-1-2-3", UID: "node-uid"}}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "app-1", Namespace: "apps", UID: "pod-uid"}}
+
+	testCases := []struct {
+		name            string
+		targets         []Target
+		expectedObjects []string
+	}{
+		{
+			name:            "case 0: event is recorded for the given object only",
+			expectedObjects: []string{"Node/ip-10-1-2-3"},
+		},
+		{
+			name:            "case 1: event is recorded for the targets too",
+			targets:         []Target{{Object: pod}},
+			expectedObjects: []string{"Node/ip-10-1-2-3", "Pod/app-1"},
+		},
+		{
+			name:            "case 2: targets without object are skipped",
+			targets:         []Target{{}},
+			expectedObjects: []string{"Node/ip-10-1-2-3"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sClient := fake.NewSimpleClientset()
+			r := newWorkloadClusterRecorder("node-operator", k8sClient)
+
+			ctx := WithTargets(context.Background(), tc.targets...)
+			r.Warn(ctx, node, "DrainingFailed", "failed to drain node")
+
+			events, err := k8sClient.CoreV1().Events(metav1.NamespaceAll).List(context.Background(), metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var objects []string
+			for _, e := range events.Items {
+				if e.Type != corev1.EventTypeWarning || e.Reason != "DrainingFailed" || e.Message != "Failed to drain node" {
+					t.Fatalf("unexpected event %#v", e)
+				}
+				objects = append(objects, e.InvolvedObject.Kind+"/"+e.InvolvedObject.Name)
+			}
+
+			sort.Strings(objects)
+
+			if !cmp.Equal(tc.expectedObjects, objects) {
+				t.Fatalf("\n\n%s\n", cmp.Diff(tc.expectedObjects, objects))
+			}
+		})
+	}
+}
@@ -15,9 +15,15 @@ import (
 	"k8s.io/client-go/rest"
 
 	corev1alpha1 "github.com/giantswarm/node-operator/api"
+	corev1alpha2 "github.com/giantswarm/node-operator/api/v1alpha2"
 	"github.com/giantswarm/node-operator/flag"
 	"github.com/giantswarm/node-operator/pkg/project"
+	"github.com/giantswarm/node-operator/service/admission"
 	"github.com/giantswarm/node-operator/service/controller"
+	"github.com/giantswarm/node-operator/service/controller/resource/drainer"
+	"github.com/giantswarm/node-operator/service/conversion"
+	"github.com/giantswarm/node-operator/service/eventtarget"
+	"github.com/giantswarm/node-operator/service/executor"
 	"github.com/giantswarm/node-operator/service/recorder"
 )
 
@@ -29,10 +35,17 @@ type Config struct {
 }
 
 type Service struct {
-	Version *version.Service
+	Admission  *admission.Service
+	Conversion *conversion.Service
+	Version    *version.Service
 
 	bootOnce          sync.Once
+	cancel            context.CancelFunc
+	ctx               context.Context
 	drainerController *controller.Drainer
+	drainExecutor     *executor.Executor
+	logger            micrologger.Logger
+	shutdownOnce      sync.Once
 }
 
 func New(config Config) (*Service, error) {
@@ -72,6 +85,7 @@ func New(config Config) (*Service, error) {
 			Logger: config.Logger,
 			SchemeBuilder: k8sclient.SchemeBuilder{
 				corev1alpha1.AddToScheme,
+				corev1alpha2.AddToScheme,
 				infrastructurev1alpha3.AddToScheme,
 			},
 
@@ -95,12 +109,99 @@ func New(config Config) (*Service, error) {
 		event = recorder.New(c)
 	}
 
-	var drainerController *controller.Drainer
+	controlPlaneDrainPolicy := drainer.DrainPolicy{
+		DeleteEmptyDirData:              config.Viper.GetBool(config.Flag.Service.Drain.ControlPlane.DeleteEmptyDirData),
+		DisableEviction:                 config.Viper.GetBool(config.Flag.Service.Drain.ControlPlane.DisableEviction),
+		Force:                           config.Viper.GetBool(config.Flag.Service.Drain.ControlPlane.Force),
+		GracePeriodSeconds:              config.Viper.GetInt(config.Flag.Service.Drain.ControlPlane.GracePeriodSeconds),
+		SkipWaitForDeleteTimeoutSeconds: config.Viper.GetInt(config.Flag.Service.Drain.ControlPlane.SkipWaitForDeleteTimeoutSeconds),
+		Timeout:                         config.Viper.GetDuration(config.Flag.Service.Drain.ControlPlane.Timeout),
+	}
+	workerDrainPolicy := drainer.DrainPolicy{
+		DeleteEmptyDirData:              config.Viper.GetBool(config.Flag.Service.Drain.Worker.DeleteEmptyDirData),
+		DisableEviction:                 config.Viper.GetBool(config.Flag.Service.Drain.Worker.DisableEviction),
+		Force:                           config.Viper.GetBool(config.Flag.Service.Drain.Worker.Force),
+		GracePeriodSeconds:              config.Viper.GetInt(config.Flag.Service.Drain.Worker.GracePeriodSeconds),
+		SkipWaitForDeleteTimeoutSeconds: config.Viper.GetInt(config.Flag.Service.Drain.Worker.SkipWaitForDeleteTimeoutSeconds),
+		Timeout:                         config.Viper.GetDuration(config.Flag.Service.Drain.Worker.Timeout),
+	}
+
+	var admissionService *admission.Service
 	{
-		c := controller.DrainerConfig{
-			Event:     event,
+		c := admission.Config{
 			K8sClient: k8sClient,
 			Logger:    config.Logger,
+
+			ControlPlaneDrainPolicy: controlPlaneDrainPolicy,
+			WorkerDrainPolicy:       workerDrainPolicy,
+		}
+
+		admissionService, err = admission.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var conversionService *conversion.Service
+	{
+		c := conversion.Config{
+			K8sClient: k8sClient,
+			Logger:    config.Logger,
+
+			CAFile:           config.Viper.GetString(config.Flag.Service.Webhook.TLS.CAFile),
+			ServiceName:      config.Viper.GetString(config.Flag.Service.Webhook.Service.Name),
+			ServiceNamespace: config.Viper.GetString(config.Flag.Service.Webhook.Service.Namespace),
+			ServicePort:      config.Viper.GetInt32(config.Flag.Service.Webhook.Service.Port),
+		}
+
+		conversionService, err = conversion.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var drainExecutor *executor.Executor
+	{
+		c := executor.Config{
+			Logger: config.Logger,
+
+			ClusterWorkers: config.Viper.GetInt(config.Flag.Service.Drain.Executor.ClusterWorkers),
+			QueueSize:      config.Viper.GetInt(config.Flag.Service.Drain.Executor.QueueSize),
+			Workers:        config.Viper.GetInt(config.Flag.Service.Drain.Executor.Workers),
+		}
+
+		drainExecutor, err = executor.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var eventTarget *eventtarget.Resolver
+	{
+		c := eventtarget.Config{
+			Client: k8sClient.CtrlClient(),
+
+			Kind: config.Viper.GetString(config.Flag.Service.Event.Target),
+		}
+
+		eventTarget, err = eventtarget.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var drainerController *controller.Drainer
+	{
+		c := controller.DrainerConfig{
+			Event:       event,
+			EventTarget: eventTarget,
+			Executor:    drainExecutor,
+			K8sClient:   k8sClient,
+			Logger:      config.Logger,
+
+			ClientCacheTTL:          config.Viper.GetDuration(config.Flag.Service.WorkloadCluster.ClientCache.TTL),
+			ControlPlaneDrainPolicy: controlPlaneDrainPolicy,
+			WorkerDrainPolicy:       workerDrainPolicy,
 		}
 
 		drainerController, err = controller.NewDrainer(c)
@@ -125,11 +226,22 @@ func New(config Config) (*Service, error) {
 		}
 	}
 
+	// The context the service boots with is canceled on shutdown, which stops
+	// the drains in flight.
+	ctx, cancel := context.WithCancel(context.Background())
+
 	newService := &Service{
-		Version: versionService,
+		Admission:  admissionService,
+		Conversion: conversionService,
+		Version:    versionService,
 
 		bootOnce:          sync.Once{},
+		cancel:            cancel,
+		ctx:               ctx,
 		drainerController: drainerController,
+		drainExecutor:     drainExecutor,
+		logger:            config.Logger,
+		shutdownOnce:      sync.Once{},
 	}
 
 	return newService, nil
@@ -137,6 +249,27 @@ func New(config Config) (*Service, error) {
 
 func (s *Service) Boot() {
 	s.bootOnce.Do(func() {
-		go s.drainerController.Boot(context.Background())
+		ctx := s.ctx
+
+		// DrainerConfigs are stored as v1alpha2. Producers still using v1alpha1
+		// rely on the conversion webhook, so we make sure the CRD calls it.
+		err := s.Conversion.EnsureCRD(ctx)
+		if err != nil {
+			s.logger.LogCtx(ctx, "level", "error", "message", "failed to configure CRD conversion webhook", "stack", microerror.JSON(err))
+		}
+
+		s.drainExecutor.Boot(ctx)
+
+		go s.drainerController.Boot(ctx)
+	})
+}
+
+// Shutdown stops the drain executor, canceling the drains in flight, and the
+// controller.
+func (s *Service) Shutdown() {
+	s.shutdownOnce.Do(func() {
+		s.logger.Log("level", "debug", "message", "shutting down service")
+
+		s.cancel()
 	})
 }